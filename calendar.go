@@ -0,0 +1,58 @@
+package main
+
+import (
+	"cron_expression_parser/calendar"
+	"fmt"
+	"time"
+)
+
+func runCalendar(c *cli, args []string) int {
+	flags := c.flagSet("calendar", "<expression | ->", "Prints month grids marking the days the expression fires on, with the number of runs per day.")
+	monthFlag := flags.String("month", "", "first month to show, e.g. 2026-11 (default current month)")
+	months := flags.Int("months", 1, "number of months to show")
+	heatmap := flags.Bool("heatmap", false, "print runs per hour and weekday over the months instead")
+	dayPolicy := dayPolicyFlag(flags)
+	args, code, ok := parseFlags(flags, args)
+	if !ok {
+		return code
+	}
+
+	if *months < 1 {
+		return usageError(flags, "-months should be at least 1")
+	}
+
+	now := time.Now()
+	first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if *monthFlag != "" {
+		var err error
+		first, err = time.Parse("2006-01", *monthFlag)
+		if err != nil {
+			return usageError(flags, "Cannot parse month %q, should be like 2026-11", *monthFlag)
+		}
+	}
+
+	schedule, code, ok := c.expressionArg(flags, args, *dayPolicy)
+	if !ok {
+		return code
+	}
+
+	if *heatmap {
+		err := calendar.Heatmap(c.stdout, schedule, first, first.AddDate(0, *months, 0))
+		if err != nil {
+			return c.fail(err)
+		}
+		return exitOK
+	}
+
+	for i := 0; i < *months; i++ {
+		if i > 0 {
+			fmt.Fprintln(c.stdout)
+		}
+		month := first.AddDate(0, i, 0)
+		err := calendar.Month(c.stdout, schedule, month.Year(), month.Month())
+		if err != nil {
+			return c.fail(err)
+		}
+	}
+	return exitOK
+}
@@ -0,0 +1,109 @@
+// Package calendar renders when a schedule fires as text, either as a month
+// grid like cal(1) or as a heatmap of runs per hour and weekday.
+package calendar
+
+import (
+	"cron_expression_parser/parser"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const cellWidth = 6
+
+var weekdays = [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+// shades are used by the heatmap from the fewest to the most runs.
+var shades = []string{"░", "▒", "▓", "█"}
+
+// Month writes the grid of a month. Days on which the schedule fires are
+// marked with "*" and the number of their runs is written below them.
+func Month(w io.Writer, schedule *parser.Schedule, year int, month time.Month) error {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	days := first.AddDate(0, 1, -1).Day()
+
+	var sb strings.Builder
+	title := fmt.Sprintf("%s %d", month, year)
+	width := cellWidth * len(weekdays)
+	fmt.Fprintf(&sb, "%*s\n", (width+len(title))/2, title)
+	header := ""
+	for _, name := range weekdays {
+		// Names line up with the day numbers, which leave room for the mark.
+		header += fmt.Sprintf("%*s ", cellWidth-1, name)
+	}
+	sb.WriteString(strings.TrimRight(header, " ") + "\n")
+
+	fireDays, runs := 0, 0
+	dayRow, runRow := strings.Builder{}, strings.Builder{}
+	column := int(first.Weekday())
+	dayRow.WriteString(strings.Repeat(" ", column*cellWidth))
+	runRow.WriteString(strings.Repeat(" ", column*cellWidth))
+	for day := 1; day <= days; day++ {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		if schedule.FiresOn(date) {
+			fmt.Fprintf(&dayRow, "%*d*", cellWidth-1, day)
+			fmt.Fprintf(&runRow, "%*d ", cellWidth-1, schedule.RunsPerDay())
+			fireDays++
+			runs += schedule.RunsPerDay()
+		} else {
+			fmt.Fprintf(&dayRow, "%*d ", cellWidth-1, day)
+			runRow.WriteString(strings.Repeat(" ", cellWidth))
+		}
+
+		column++
+		if column == len(weekdays) || day == days {
+			sb.WriteString(strings.TrimRight(dayRow.String(), " ") + "\n")
+			sb.WriteString(strings.TrimRight(runRow.String(), " ") + "\n")
+			dayRow.Reset()
+			runRow.Reset()
+			column = 0
+		}
+	}
+	fmt.Fprintf(&sb, "%d runs on %d days\n", runs, fireDays)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Heatmap writes the number of runs for every hour and weekday between
+// from and to, excluding to. Only the dates of from and to are used.
+func Heatmap(w io.Writer, schedule *parser.Schedule, from, to time.Time) error {
+	counts := [24][7]int{}
+	maxCount := 0
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	for date := start; date.Before(end); date = date.AddDate(0, 0, 1) {
+		if !schedule.FiresOn(date) {
+			continue
+		}
+		for hour := range counts {
+			counts[hour][date.Weekday()] += schedule.RunsInHour(hour)
+			maxCount = max(maxCount, counts[hour][date.Weekday()])
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Runs per hour and weekday, %s to %s\n", start.Format(time.DateOnly), end.AddDate(0, 0, -1).Format(time.DateOnly))
+	sb.WriteString("    ")
+	for _, name := range weekdays {
+		fmt.Fprintf(&sb, "%*s", cellWidth, name)
+	}
+	sb.WriteString("\n")
+
+	for hour, row := range counts {
+		fmt.Fprintf(&sb, "%02dh ", hour)
+		for _, count := range row {
+			if count == 0 {
+				sb.WriteString(strings.Repeat(" ", cellWidth-1) + "·")
+				continue
+			}
+			shade := shades[(count*len(shades)-1)/maxCount]
+			fmt.Fprintf(&sb, " %s%*d", shade, cellWidth-2, count)
+		}
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,64 @@
+package calendar
+
+import (
+	"cron_expression_parser/parser"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, input string) *parser.Schedule {
+	schedule, err := parser.ParseTimeFields(input)
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+	return schedule
+}
+
+func TestShouldRenderMonthGrid(t *testing.T) {
+	var sb strings.Builder
+	err := Month(&sb, mustParse(t, "*/15 9-17 10 * FRI"), 2026, time.November)
+	if err != nil {
+		t.Fatalf("Should render month; %s", err)
+	}
+
+	expected := `              November 2026
+   Su    Mo    Tu    We    Th    Fr    Sa
+    1     2     3     4     5     6*    7
+                                 36
+    8     9    10*   11    12    13*   14
+               36                36
+   15    16    17    18    19    20*   21
+                                 36
+   22    23    24    25    26    27*   28
+                                 36
+   29    30
+
+180 runs on 5 days
+`
+	if sb.String() != expected {
+		t.Fatalf("Should render month grid, expected:\n%s\nactual:\n%s", expected, sb.String())
+	}
+}
+
+func TestShouldRenderHeatmap(t *testing.T) {
+	var sb strings.Builder
+	from := time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)
+	err := Heatmap(&sb, mustParse(t, "*/15 9 10 * FRI"), from, from.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("Should render heatmap; %s", err)
+	}
+
+	lines := strings.Split(sb.String(), "\n")
+	if lines[0] != "Runs per hour and weekday, 2026-11-01 to 2026-11-30" {
+		t.Fatalf("Should render title, actual: %q", lines[0])
+	}
+
+	if lines[11] != "09h      ·     · ░   4     ·     · █  16     ·" {
+		t.Fatalf("Should count runs of 9 o'clock, actual: %q", lines[11])
+	}
+
+	if lines[12] != "10h      ·     ·     ·     ·     ·     ·     ·" {
+		t.Fatalf("Should not count other hours, actual: %q", lines[12])
+	}
+}
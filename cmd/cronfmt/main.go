@@ -0,0 +1,62 @@
+// Cronfmt formats crontab files, like gofmt does for Go sources.
+//
+// Usage:
+//
+//	cronfmt [-l] [-d] [-w] [-names] [path ...]
+//
+// Without paths it formats standard input to standard output.
+package main
+
+import (
+	"cron_expression_parser/parser/crontab"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+var (
+	list  = flag.Bool("l", false, "list files whose formatting differs from cronfmt's")
+	diff  = flag.Bool("d", false, "display diffs instead of rewriting files")
+	write = flag.Bool("w", false, "write result to (source) file instead of stdout")
+	names = flag.Bool("names", false, "write months and days of week as names (1-5 becomes MON-FRI)")
+)
+
+func main() {
+	flag.Parse()
+
+	exitCode := 0
+	if flag.NArg() == 0 {
+		if err := processFile("<standard input>", os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			exitCode = 2
+		}
+		os.Exit(exitCode)
+	}
+
+	for _, path := range flag.Args() {
+		file, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			exitCode = 2
+			continue
+		}
+
+		err = processFile(path, file, os.Stdout)
+		file.Close()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			exitCode = 2
+		}
+	}
+	os.Exit(exitCode)
+}
+
+func processFile(path string, in io.Reader, out io.Writer) error {
+	return crontab.FormatFile(path, in, out, crontab.FormatFileOptions{
+		FormatOptions: crontab.FormatOptions{Names: *names},
+		List:          *list,
+		Diff:          *diff,
+		Write:         *write,
+	})
+}
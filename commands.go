@@ -0,0 +1,246 @@
+package main
+
+import (
+	"cron_expression_parser/parser"
+	"cron_expression_parser/parser/crontab"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+func runExplain(c *cli, args []string) int {
+	flags := c.flagSet("explain", "<expression | ->", "Prints the values of every field of the expression, or of every entry of a crontab.")
+	output := flags.String("output", "text", "output format: text, json, yaml, toml or csv")
+	next := flags.Int("next", 0, "number of next run times to include in the output")
+	crontabPath := flags.String("crontab", "", "explain every entry of the given crontab file, - for stdin")
+	dayPolicy := dayPolicyFlag(flags)
+	args, code, ok := parseFlags(flags, args)
+	if !ok {
+		return code
+	}
+
+	renderer, err := parser.NewRenderer(*output)
+	if err != nil {
+		return usageError(flags, "%s", err)
+	}
+
+	if *crontabPath != "" {
+		file, code, ok := c.crontabArg(*crontabPath)
+		if !ok {
+			return code
+		}
+
+		results := []parser.Result{}
+		for _, entry := range file.Entries() {
+			results = append(results, entry.Expression.Result(time.Now(), *next))
+		}
+		return c.render(renderer, results)
+	}
+
+	schedule, code, ok := c.expressionArg(flags, args, *dayPolicy)
+	if !ok {
+		return code
+	}
+
+	for _, warning := range schedule.Warnings() {
+		fmt.Fprintln(c.stderr, "warning:", warning)
+	}
+	return c.render(renderer, []parser.Result{schedule.Result(time.Now(), *next)})
+}
+
+func (c *cli) render(renderer parser.Renderer, results []parser.Result) int {
+	err := renderer.Render(c.stdout, results)
+	if err != nil {
+		return c.fail(err)
+	}
+	return exitOK
+}
+
+func runDescribe(c *cli, args []string) int {
+	flags := c.flagSet("describe", "<expression | ->", "Prints the expression as a sentence.")
+	localeName := flags.String("locale", "en", "language of the description (de, en, es, fr, pl)")
+	dayPolicy := dayPolicyFlag(flags)
+	args, code, ok := parseFlags(flags, args)
+	if !ok {
+		return code
+	}
+
+	schedule, code, ok := c.expressionArg(flags, args, *dayPolicy)
+	if !ok {
+		return code
+	}
+
+	description, err := schedule.DescribeIn(*localeName)
+	if err != nil {
+		return usageError(flags, "%s", err)
+	}
+	fmt.Fprintln(c.stdout, description)
+	return exitOK
+}
+
+func runValidate(c *cli, args []string) int {
+	flags := c.flagSet("validate", "<expression | ->", "Exits with 0 when the expression or crontab is valid and with 1 when it is not.")
+	crontabPath := flags.String("crontab", "", "validate the given crontab file instead, - for stdin")
+	quiet := flags.Bool("q", false, "do not print anything, only set the exit code")
+	dayPolicy := dayPolicyFlag(flags)
+	args, code, ok := parseFlags(flags, args)
+	if !ok {
+		return code
+	}
+
+	if *quiet {
+		c = &cli{stdin: c.stdin, stdout: io.Discard, stderr: io.Discard}
+	}
+
+	if *crontabPath != "" {
+		file, code, ok := c.crontabArg(*crontabPath)
+		if !ok {
+			return code
+		}
+		fmt.Fprintf(c.stdout, "valid: %d entries\n", len(file.Entries()))
+		return exitOK
+	}
+
+	_, code, ok = c.expressionArg(flags, args, *dayPolicy)
+	if !ok {
+		return code
+	}
+	fmt.Fprintln(c.stdout, "valid")
+	return exitOK
+}
+
+func runConvert(c *cli, args []string) int {
+	flags := c.flagSet("convert", "<expression | ->", "Rewrites the expression in another notation, or exports it as an iCalendar event.")
+	to := flags.String("to", "cron", "target notation: cron (shortest numeric form), names (JAN-DEC, SUN-SAT) or ical (.ics event)")
+	options := icalFlags(flags)
+	dayPolicy := dayPolicyFlag(flags)
+	args, code, ok := parseFlags(flags, args)
+	if !ok {
+		return code
+	}
+
+	if *to != "cron" && *to != "names" && *to != "ical" {
+		return usageError(flags, "Unknown notation %q, should be one of: cron, names, ical", *to)
+	}
+
+	if *to == "ical" {
+		return c.convertICal(flags, args, options, *dayPolicy)
+	}
+	if options.crontab != "" {
+		return usageError(flags, "-crontab is only supported with -to ical")
+	}
+
+	schedule, code, ok := c.expressionArg(flags, args, *dayPolicy)
+	if !ok {
+		return code
+	}
+
+	fields := schedule.CanonicalFields(*to == "names")
+	if schedule.Command() != "" {
+		fields = append(fields, schedule.Command())
+	}
+	fmt.Fprintln(c.stdout, strings.Join(fields, " "))
+	return exitOK
+}
+
+func runLint(c *cli, args []string) int {
+	flags := c.flagSet("lint", "<expression | ->", "Prints warnings about the expression or crontab and exits with 1 when there are any.")
+	crontabPath := flags.String("crontab", "", "lint every entry of the given crontab file, - for stdin")
+	dayPolicy := dayPolicyFlag(flags)
+	args, code, ok := parseFlags(flags, args)
+	if !ok {
+		return code
+	}
+
+	if *crontabPath != "" {
+		policy, err := parser.ParseDayPolicy(*dayPolicy)
+		if err != nil {
+			return usageError(flags, "%s", err)
+		}
+
+		file, code, ok := c.crontabArg(*crontabPath)
+		if !ok {
+			return code
+		}
+
+		found := 0
+		for _, entry := range file.Entries() {
+			for _, warning := range entry.Expression.WithDayPolicy(policy).Warnings() {
+				fmt.Fprintf(c.stdout, "line %d: %s\n", entry.Number, warning)
+				found++
+			}
+		}
+		return lintExitCode(found)
+	}
+
+	schedule, code, ok := c.expressionArg(flags, args, *dayPolicy)
+	if !ok {
+		return code
+	}
+
+	warnings := schedule.Warnings()
+	for _, warning := range warnings {
+		fmt.Fprintln(c.stdout, warning)
+	}
+	return lintExitCode(len(warnings))
+}
+
+func lintExitCode(warnings int) int {
+	if warnings > 0 {
+		return exitInvalid
+	}
+	return exitOK
+}
+
+func runFmt(c *cli, args []string) int {
+	flags := c.flagSet("fmt", "[path ... | -]", "Formats crontab files like gofmt, standard input when no path or - is given.")
+	list := flags.Bool("l", false, "list files whose formatting differs")
+	diff := flags.Bool("d", false, "display diffs instead of rewriting files")
+	write := flags.Bool("w", false, "write result to (source) file instead of stdout")
+	names := flags.Bool("names", false, "write months and days of week as names (1-5 becomes MON-FRI)")
+	args, code, ok := parseFlags(flags, args)
+	if !ok {
+		return code
+	}
+
+	if len(args) == 0 {
+		args = []string{"-"}
+	}
+
+	options := crontab.FormatFileOptions{FormatOptions: crontab.FormatOptions{Names: *names}, List: *list, Diff: *diff, Write: *write}
+	code = exitOK
+	for _, path := range args {
+		if path == "-" && *write {
+			return usageError(flags, "Cannot write the result to standard input")
+		}
+
+		in, err := c.openInput(path)
+		if err != nil {
+			code = c.fail(err)
+			continue
+		}
+		err = crontab.FormatFile(path, in, c.stdout, options)
+		in.Close()
+		if err != nil {
+			code = c.fail(err)
+		}
+	}
+	return code
+}
+
+// crontabArg parses the crontab file at path. ok is false when the command
+// should exit with code.
+func (c *cli) crontabArg(path string) (file *crontab.File, code int, ok bool) {
+	in, err := c.openInput(path)
+	if err != nil {
+		return nil, c.fail(err), false
+	}
+	defer in.Close()
+
+	file, err = crontab.Parse(in)
+	if err != nil {
+		return nil, c.fail(fmt.Errorf("%s: %w", path, err)), false
+	}
+	return file, exitOK, true
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"cron_expression_parser/daemon"
+	"cron_expression_parser/executor"
+	"cron_expression_parser/notify"
+	"cron_expression_parser/scheduler"
+	"cron_expression_parser/store"
+	"cron_expression_parser/store/sqlite"
+	"log/slog"
+	"os"
+	"os/signal"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+func runDaemon(c *cli, args []string) int {
+	flags := c.flagSet("daemon", "crontab...", "Runs the jobs of the crontab files, reloading them on change and on SIGHUP.")
+	poll := flags.Duration("poll", daemon.DefaultPollInterval, "how often the crontab files are checked for changes")
+	shutdownTimeout := flags.Duration("shutdown-timeout", daemon.DefaultShutdownTimeout, "how long running jobs are waited for on SIGTERM")
+	timeout := flags.Duration("timeout", 0, "kill jobs running longer than this, 0 means no limit")
+	maxOutput := flags.Int("max-output", executor.DefaultMaxOutput, "bytes of job output kept for logs and notifications")
+	shell := flags.String("shell", "", "shell running the commands, SHELL from the crontab or /bin/sh by default")
+	logFormat := flags.String("log-format", "json", "log format: json or text")
+	debug := flags.Bool("debug", false, "log every scheduler event")
+	smtpAddr := flags.String("smtp", "", "host:port of the mail server used for MAILTO")
+	mailFrom := flags.String("mail-from", "", "sender of the mails")
+	webhook := flags.String("webhook", "", "URL receiving every run as JSON")
+	notifyFile := flags.String("notify-file", "", "file every run is appended to as a JSON line")
+	storePath := flags.String("store", "", "file keeping the last runs of entries with an \"# id:\" comment, SQLite for .db, JSON otherwise")
+	catchUpFlag := flags.String("catch-up", "none", "what to do with runs missed while stopped, needs --store: none, once or all")
+	startingDeadline := flags.Duration("starting-deadline", 0, "drop runs that could not start within this of their fire time, 0 means no deadline")
+	args, code, ok := parseFlags(flags, args)
+	if !ok {
+		return code
+	}
+
+	if len(args) == 0 {
+		return usageError(flags, "Please provide at least one crontab file")
+	}
+
+	level := slog.LevelInfo
+	if *debug {
+		level = slog.LevelDebug
+	}
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch strings.ToLower(*logFormat) {
+	case "json":
+		handler = slog.NewJSONHandler(c.stderr, options)
+	case "text":
+		handler = slog.NewTextHandler(c.stderr, options)
+	default:
+		return usageError(flags, "Unknown log format %q, should be one of: json, text", *logFormat)
+	}
+
+	catchUp, err := scheduler.ParseCatchUpPolicy(*catchUpFlag)
+	if err != nil {
+		return usageError(flags, "%s", err)
+	}
+	if catchUp != scheduler.CatchUpNone && *storePath == "" {
+		return usageError(flags, "Catching up missed runs needs --store")
+	}
+
+	notifiers := []notify.Notifier{}
+	if *smtpAddr != "" {
+		// Without MAILTO, cron mails the user running the job.
+		smtp := &notify.SMTP{Addr: *smtpAddr, From: *mailFrom}
+		if current, err := user.Current(); err == nil {
+			smtp.To = []string{current.Username}
+		}
+		notifiers = append(notifiers, smtp)
+	}
+	if *webhook != "" {
+		notifiers = append(notifiers, &notify.Webhook{URL: *webhook})
+	}
+	if *notifyFile != "" {
+		notifiers = append(notifiers, &notify.File{Path: *notifyFile})
+	}
+	var notifier notify.Notifier
+	if len(notifiers) > 0 {
+		notifier = notify.Multi(notifiers...)
+	}
+
+	var jobStore store.Store
+	if *storePath != "" {
+		jobStore, err = openStore(*storePath)
+		if err != nil {
+			return c.fail(err)
+		}
+		defer jobStore.Close()
+	}
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	d := daemon.New(daemon.Config{
+		Paths:            args,
+		PollInterval:     *poll,
+		ShutdownTimeout:  *shutdownTimeout,
+		Executor:         executor.Config{Shell: *shell, Timeout: *timeout, MaxOutput: *maxOutput},
+		Notifier:         notifier,
+		Logger:           slog.New(handler),
+		Hangup:           hangup,
+		Store:            jobStore,
+		CatchUp:          catchUp,
+		StartingDeadline: *startingDeadline,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	if err := d.Run(ctx); err != nil {
+		return exitInvalid
+	}
+	return exitOK
+}
+
+// openStore opens the SQLite store for a .db path and the JSON file store
+// for any other.
+func openStore(path string) (store.Store, error) {
+	if filepath.Ext(path) == ".db" {
+		return sqlite.Open(path)
+	}
+	return store.OpenFile(path)
+}
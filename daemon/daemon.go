@@ -0,0 +1,334 @@
+// Package daemon runs the jobs of crontab files in the foreground, reloading
+// the files when they change.
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"cron_expression_parser/executor"
+	"cron_expression_parser/notify"
+	"cron_expression_parser/parser/crontab"
+	"cron_expression_parser/scheduler"
+	"cron_expression_parser/store"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultPollInterval    = 5 * time.Second
+	DefaultShutdownTimeout = 30 * time.Second
+	DefaultNotifyTimeout   = 30 * time.Second
+)
+
+type Config struct {
+	// Paths are the crontab files to run.
+	Paths []string
+	// PollInterval is how often the files are checked for changes.
+	PollInterval time.Duration
+	// ShutdownTimeout is how long running jobs are waited for on stop
+	// before their processes are killed.
+	ShutdownTimeout time.Duration
+	// Executor is the base configuration of every job. Env and Shell are
+	// filled from the crontab of the job.
+	Executor executor.Config
+	// Notifier gets the output of every run, subject to MAILTO of the
+	// job. Nil means that output is only logged.
+	Notifier notify.Notifier
+	// NotifyTimeout bounds the delivery of one notification, so a stuck
+	// mail server or webhook does not hold up the job or the shutdown.
+	NotifyTimeout time.Duration
+	Logger        *slog.Logger
+	// Clock is passed to the scheduler, RealClock by default.
+	Clock scheduler.Clock
+	// Hangup reloads the files whenever it receives, e.g. on SIGHUP.
+	Hangup <-chan os.Signal
+
+	// Store keeps the last and next runs and the run history of the entries
+	// with an "# id:" comment, so runs missed while the daemon was down are
+	// caught up as CatchUp says.
+	// Other entries are known by their line number, which changes with
+	// edits, and are never caught up. Nil keeps no state.
+	Store   store.Store
+	CatchUp scheduler.CatchUpPolicy
+	// StartingDeadline drops runs that could not start within it of their
+	// fire time. Zero means no deadline.
+	StartingDeadline time.Duration
+}
+
+// job is an entry of a crontab file registered in the scheduler.
+type job struct {
+	id    scheduler.JobID
+	key   string
+	name  string
+	entry crontab.Line
+	env   map[string]string
+}
+
+type Daemon struct {
+	config    Config
+	logger    *slog.Logger
+	scheduler *scheduler.Scheduler
+
+	mu      sync.Mutex
+	jobs    map[string]job
+	reboots []job
+	hashes  map[string][sha256.Size]byte
+
+	// rebooting tracks the "@reboot" jobs started by Run.
+	rebooting sync.WaitGroup
+}
+
+func New(config Config) *Daemon {
+	if config.PollInterval == 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+	if config.ShutdownTimeout == 0 {
+		config.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	if config.NotifyTimeout == 0 {
+		config.NotifyTimeout = DefaultNotifyTimeout
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+	if config.Executor.KillDelay == 0 {
+		config.Executor.KillDelay = executor.DefaultKillDelay
+	}
+
+	d := &Daemon{config: config, logger: config.Logger, jobs: map[string]job{}, hashes: map[string][sha256.Size]byte{}}
+	// Killed jobs are waited for, so their process groups are gone when Run
+	// returns.
+	d.scheduler = scheduler.New(scheduler.Config{Clock: config.Clock, OnEvent: d.logEvent, Store: config.Store, CancelGrace: config.Executor.KillDelay})
+	return d
+}
+
+// Reload parses all crontab files again and applies the changes to the
+// registered jobs, see apply. When any file cannot be read or parsed, the
+// running jobs are kept and the error is returned.
+func (d *Daemon) Reload() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	files, hashes, err := d.load()
+	if err != nil {
+		d.logger.Error("reload failed", "err", err)
+		return err
+	}
+	d.apply(files)
+	d.reboots = rebootJobs(files)
+	d.hashes = hashes
+	return nil
+}
+
+// Run starts the jobs and watches the files until ctx is done, then waits
+// for running jobs up to the shutdown timeout.
+func (d *Daemon) Run(ctx context.Context) error {
+	if err := d.Reload(); err != nil {
+		return err
+	}
+	d.scheduler.Start()
+	d.logger.Info("daemon started", "files", d.config.Paths, "jobs", len(d.Entries()))
+	// "@reboot" jobs are not stopped by ctx, they get the shutdown timeout
+	// like the scheduled ones.
+	rebootCtx, cancelReboots := context.WithCancel(context.Background())
+	defer cancelReboots()
+	d.startReboots(rebootCtx)
+
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if d.changed() {
+				d.logger.Info("crontab changed, reloading")
+				d.Reload()
+			}
+		case <-d.config.Hangup:
+			d.logger.Info("reload requested")
+			d.Reload()
+		case <-ctx.Done():
+			d.logger.Info("daemon stopping", "timeout", d.config.ShutdownTimeout.String())
+			stopCtx, cancel := context.WithTimeout(context.Background(), d.config.ShutdownTimeout)
+			defer cancel()
+			err := d.scheduler.Stop(stopCtx)
+			rebootErr := d.waitReboots(stopCtx, cancelReboots)
+			if err == nil {
+				err = rebootErr
+			}
+			if err != nil {
+				d.logger.Warn("running jobs killed", "err", err)
+			}
+			d.logger.Info("daemon stopped")
+			return nil
+		}
+	}
+}
+
+// startReboots runs the "@reboot" entries of the files once, as cron does
+// when it starts. Entries added by later reloads are not run.
+func (d *Daemon) startReboots(ctx context.Context) {
+	d.mu.Lock()
+	reboots := d.reboots
+	d.mu.Unlock()
+
+	for _, j := range reboots {
+		d.logger.Info("job started at boot", "job", j.name, "command", j.entry.Command)
+		run := d.runFunc(j)
+		d.rebooting.Add(1)
+		go func() {
+			defer d.rebooting.Done()
+			run(ctx)
+		}()
+	}
+}
+
+// waitReboots waits for the "@reboot" jobs until ctx is done. Then it
+// cancels them and waits up to the kill delay for their processes.
+func (d *Daemon) waitReboots(ctx context.Context, cancel context.CancelFunc) error {
+	done := make(chan struct{})
+	go func() {
+		d.rebooting.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	cancel()
+	grace := time.NewTimer(d.config.Executor.KillDelay)
+	defer grace.Stop()
+	select {
+	case <-done:
+	case <-grace.C:
+	}
+	return ctx.Err()
+}
+
+// Entries returns the registered jobs ordered by their next run.
+func (d *Daemon) Entries() []scheduler.Entry {
+	return d.scheduler.Entries()
+}
+
+type loadedFile struct {
+	path string
+	file *crontab.File
+}
+
+func (d *Daemon) load() ([]loadedFile, map[string][sha256.Size]byte, error) {
+	files := []loadedFile{}
+	hashes := map[string][sha256.Size]byte{}
+	for _, path := range d.config.Paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		file, err := crontab.Parse(bytes.NewReader(content))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+		files = append(files, loadedFile{path: path, file: file})
+		hashes[path] = sha256.Sum256(content)
+	}
+	return files, hashes, nil
+}
+
+// changed reports whether any file differs from its last loaded content.
+func (d *Daemon) changed() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, path := range d.config.Paths {
+		content, err := os.ReadFile(path)
+		if err != nil || sha256.Sum256(content) != d.hashes[path] {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Daemon) runFunc(j job) func(ctx context.Context) {
+	config := d.config.Executor
+	config.Env = j.env
+	e := executor.New(config)
+
+	var notifier notify.Notifier
+	if d.config.Notifier != nil {
+		notifier = notify.ForEnv(j.env, d.config.Notifier)
+	}
+
+	return func(ctx context.Context) {
+		result := e.Run(ctx, j.entry.Command)
+		attrs := []any{
+			"job", j.name,
+			"command", result.Command,
+			"exitCode", result.ExitCode,
+			"duration", result.FinishedAt.Sub(result.StartedAt).String(),
+			"outputBytes", len(result.Output),
+		}
+		if result.Success() {
+			d.logger.Info("job succeeded", attrs...)
+		} else {
+			d.logger.Warn("job failed", append(attrs, "err", result.Err, "timedOut", result.TimedOut)...)
+		}
+		d.recordRun(ctx, j, result)
+
+		if notifier == nil {
+			return
+		}
+		// A killed job is still reported, within its own timeout.
+		notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), d.config.NotifyTimeout)
+		defer cancel()
+		err := notifier.Notify(notifyCtx, notify.Notification{Job: j.name, Result: result})
+		if err != nil {
+			d.logger.Error("notification failed", "job", j.name, "err", err)
+		}
+	}
+}
+
+// recordRun adds a scheduled run of the job to its history in the store and
+// keeps its next run there. "@reboot" runs have no fire time and are not
+// recorded.
+func (d *Daemon) recordRun(ctx context.Context, j job, result executor.Result) {
+	fireTime, ok := scheduler.FireTime(ctx)
+	if !ok || !d.keepsState(j) {
+		return
+	}
+
+	run := store.Run{
+		JobName:    j.name,
+		FireTime:   fireTime,
+		StartedAt:  result.StartedAt,
+		FinishedAt: result.FinishedAt,
+		ExitCode:   result.ExitCode,
+	}
+	if result.Err != nil {
+		run.Error = result.Err.Error()
+	}
+	err := d.config.Store.AddRun(run)
+	if err == nil {
+		err = d.config.Store.SetNextRun(j.name, j.entry.Expression.Next(fireTime))
+	}
+	if err != nil {
+		d.logger.Error("job state not saved", "job", j.name, "err", err)
+	}
+}
+
+func (d *Daemon) logEvent(event scheduler.Event) {
+	attrs := []any{"event", event.Type.String(), "job", event.Name, "fireTime", event.FireTime}
+	switch {
+	case event.Err != nil:
+		d.logger.Error("scheduler event", append(attrs, "err", event.Err)...)
+		return
+	case event.Type == scheduler.EventSkipped:
+		d.logger.Warn("scheduler event", attrs...)
+		return
+	}
+	d.logger.Debug("scheduler event", attrs...)
+}
@@ -0,0 +1,282 @@
+//go:build unix
+
+package daemon
+
+import (
+	"context"
+	"cron_expression_parser/notify"
+	"cron_expression_parser/parser/crontab"
+	"cron_expression_parser/scheduler"
+	"cron_expression_parser/store"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func writeCrontab(t *testing.T, path, content string) {
+	err := os.WriteFile(path, []byte(content), 0o644)
+	if err != nil {
+		t.Fatalf("Should write crontab; %s", err)
+	}
+}
+
+func newDaemon(paths ...string) *Daemon {
+	return New(Config{
+		Paths:        paths,
+		PollInterval: 10 * time.Millisecond,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+}
+
+func entryNames(d *Daemon) []string {
+	names := []string{}
+	for _, entry := range d.Entries() {
+		names = append(names, entry.Name)
+	}
+	return names
+}
+
+func TestShouldKeepJobsWhenReloadFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crontab")
+	writeCrontab(t, path, "0 * * * * first\n0 0 * * * second\n")
+	d := newDaemon(path)
+
+	err := d.Reload()
+	if err != nil || len(d.Entries()) != 2 {
+		t.Fatalf("Should load both entries, actual: %v; %v", entryNames(d), err)
+	}
+
+	writeCrontab(t, path, "0 * * * * first\n61 0 * * * second\n")
+	err = d.Reload()
+
+	var lineErr *crontab.LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 2 {
+		t.Fatalf("Should return error of line 2, actual: %v", err)
+	}
+
+	if len(d.Entries()) != 2 {
+		t.Fatalf("Should keep running jobs, actual: %v", entryNames(d))
+	}
+}
+
+func TestShouldReloadChangedCrontab(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crontab")
+	writeCrontab(t, path, "0 * * * * first\n")
+	d := newDaemon(path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- d.Run(ctx) }()
+
+	writeCrontab(t, path, "0 * * * * first\n0 0 * * * second\n")
+	deadline := time.Now().Add(5 * time.Second)
+	for len(d.Entries()) != 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if len(d.Entries()) != 2 {
+		t.Fatalf("Should pick up the new entry, actual: %v", entryNames(d))
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Should stop cleanly; %s", err)
+	}
+}
+
+func TestShouldRunEntryWithItsEnvironment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crontab")
+	writeCrontab(t, path, "GREETING=hello\nMAILTO=ops@example.com\n* * * * * echo $GREETING\nMAILTO=\"\"\n* * * * * echo quiet\n")
+
+	var mu sync.Mutex
+	notifications := []notify.Notification{}
+	d := newDaemon(path)
+	d.config.Notifier = notify.Func(func(ctx context.Context, n notify.Notification) error {
+		mu.Lock()
+		defer mu.Unlock()
+		notifications = append(notifications, n)
+		return nil
+	})
+	d.Reload()
+
+	for _, j := range d.jobs {
+		d.runFunc(j)(context.Background())
+	}
+
+	if len(notifications) != 1 {
+		t.Fatalf("Should notify only the job with MAILTO, actual: %d", len(notifications))
+	}
+
+	n := notifications[0]
+	if !strings.HasSuffix(n.Job, ":3") || string(n.Result.Output) != "hello\n" || n.MailTo[0] != "ops@example.com" {
+		t.Fatalf("Should run job with crontab environment, actual: %+v", n)
+	}
+}
+
+func TestShouldGiveUpNotifyingAfterTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crontab")
+	writeCrontab(t, path, "* * * * * echo hi\n")
+	d := newDaemon(path)
+	d.config.NotifyTimeout = 20 * time.Millisecond
+	d.config.Notifier = notify.Func(func(ctx context.Context, n notify.Notification) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	d.Reload()
+
+	finished := make(chan struct{})
+	go func() {
+		for _, j := range d.jobs {
+			d.runFunc(j)(context.Background())
+		}
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Should not wait for a stuck notifier")
+	}
+}
+
+func TestShouldRunRebootEntriesOnStart(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "crontab")
+	marker := filepath.Join(dir, "booted")
+	writeCrontab(t, path, "@reboot touch "+marker+"\n0 0 * * * nightly\n")
+	d := newDaemon(path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- d.Run(ctx) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(marker); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Fatalf("Should run @reboot entry; %s", err)
+	}
+	if len(d.Entries()) != 1 {
+		t.Fatalf("Should schedule only the other entry, actual: %v", entryNames(d))
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Should stop cleanly; %s", err)
+	}
+}
+
+func TestShouldGiveRebootEntriesTheShutdownTimeout(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "crontab")
+	marker := filepath.Join(dir, "finished")
+	writeCrontab(t, path, "@reboot sleep 0.2 && touch "+marker+"\n")
+	d := newDaemon(path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := d.Run(ctx); err != nil {
+		t.Fatalf("Should stop cleanly; %s", err)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Fatalf("Should let @reboot entry finish within the shutdown timeout; %s", err)
+	}
+}
+
+func TestShouldReloadOnHangupAndStopListening(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crontab")
+	writeCrontab(t, path, "0 * * * * first\n")
+	hangup := make(chan os.Signal)
+	d := newDaemon(path)
+	d.config.PollInterval = time.Hour
+	d.config.Hangup = hangup
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- d.Run(ctx) }()
+
+	writeCrontab(t, path, "0 * * * * first\n0 0 * * * second\n")
+	hangup <- syscall.SIGHUP
+	// The hangup is received by the loop, so the reload is done once the
+	// next one can be sent.
+	hangup <- syscall.SIGHUP
+	if len(d.Entries()) != 2 {
+		t.Fatalf("Should reload on hangup, actual: %v", entryNames(d))
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Should stop cleanly; %s", err)
+	}
+	select {
+	case hangup <- syscall.SIGHUP:
+		t.Fatalf("Should not listen for hangups after stopping")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestShouldCatchUpMissedRunsOfEntriesWithID(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "crontab")
+	marker := filepath.Join(dir, "caught-up")
+	writeCrontab(t, path, "# id: report\n* * * * * touch "+marker+"\n")
+
+	jobs, err := store.OpenFile(filepath.Join(dir, "jobs.json"))
+	if err != nil {
+		t.Fatalf("Should open store; %s", err)
+	}
+	jobs.SaveJob(store.JobDefinition{Name: path + ":report", Expression: "* * * * * touch " + marker})
+	jobs.SetLastRun(path+":report", time.Now().Add(-time.Hour))
+
+	d := New(Config{
+		Paths:        []string{path},
+		PollInterval: 10 * time.Millisecond,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Store:        jobs,
+		CatchUp:      scheduler.CatchUpOnce,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- d.Run(ctx) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(marker); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Fatalf("Should catch up the missed run; %s", err)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Should stop cleanly; %s", err)
+	}
+	lastRun, ok, err := jobs.LastRun(path + ":report")
+	if err != nil || !ok || time.Since(lastRun) > 2*time.Minute {
+		t.Fatalf("Should keep the last run in the store, actual: %v %v", lastRun, err)
+	}
+
+	history, err := jobs.History(path+":report", 10)
+	if err != nil || len(history) == 0 || !history[0].FireTime.Equal(lastRun) || history[0].ExitCode != 0 {
+		t.Fatalf("Should add the run to the history, actual: %+v %v", history, err)
+	}
+	job, err := jobs.Job(path + ":report")
+	if err != nil || !job.NextRun.Equal(lastRun.Add(time.Minute)) {
+		t.Fatalf("Should keep the next run in the store, actual: %v %v", job.NextRun, err)
+	}
+}
@@ -0,0 +1,183 @@
+package daemon
+
+import (
+	"cron_expression_parser/parser/crontab"
+	"cron_expression_parser/scheduler"
+	"cron_expression_parser/store"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// diff counts what a reload changed.
+type diff struct {
+	added       int
+	removed     int
+	rescheduled int
+	updated     int
+	unchanged   int
+}
+
+func (d diff) String() string {
+	return fmt.Sprintf("%d added, %d removed, %d rescheduled, %d updated, %d unchanged",
+		d.added, d.removed, d.rescheduled, d.updated, d.unchanged)
+}
+
+// newJobs returns the jobs of a crontab file keyed by their identity. An
+// entry is identified by its "# id:" comment, or else by a hash of its
+// command, so changing only the schedule of an entry reschedules it.
+// Entries with the same command are told apart by their order.
+func newJobs(path string, file *crontab.File) map[string]job {
+	jobs := map[string]job{}
+	seen := map[string]int{}
+	for _, entry := range file.Entries() {
+		id, name := entry.ID, fmt.Sprintf("%s:%s", path, entry.ID)
+		if id == "" {
+			sum := sha256.Sum256([]byte(entry.Command))
+			id = "sha256:" + hex.EncodeToString(sum[:8])
+			name = fmt.Sprintf("%s:%d", path, entry.Number)
+		}
+
+		seen[id]++
+		if seen[id] > 1 {
+			id = fmt.Sprintf("%s#%d", id, seen[id])
+		}
+
+		key := path + "\x00" + id
+		jobs[key] = job{key: key, name: name, entry: entry, env: file.EnvFor(entry)}
+	}
+	return jobs
+}
+
+// rebootJobs returns the "@reboot" entries of the files.
+func rebootJobs(files []loadedFile) []job {
+	jobs := []job{}
+	for _, loaded := range files {
+		for _, entry := range loaded.file.RebootEntries() {
+			name := fmt.Sprintf("%s:%d", loaded.path, entry.Number)
+			if entry.ID != "" {
+				name = fmt.Sprintf("%s:%s", loaded.path, entry.ID)
+			}
+			jobs = append(jobs, job{name: name, entry: entry, env: loaded.file.EnvFor(entry)})
+		}
+	}
+	return jobs
+}
+
+// apply brings the scheduler in line with the entries of the files. Only
+// added, removed and changed entries are touched, so unchanged jobs keep
+// their next run and their runs in progress. It is called with d.mu held.
+func (d *Daemon) apply(files []loadedFile) {
+	next := map[string]job{}
+	for _, loaded := range files {
+		maps.Copy(next, newJobs(loaded.path, loaded.file))
+	}
+
+	summary := diff{}
+	for _, key := range sortedKeys(d.jobs) {
+		if _, ok := next[key]; ok {
+			continue
+		}
+		old := d.jobs[key]
+		d.scheduler.Remove(old.id)
+		d.forgetJob(old)
+		summary.removed++
+		d.logger.Info("job removed", "job", old.name, "schedule", old.schedule(), "command", old.entry.Command)
+	}
+
+	for _, key := range sortedKeys(next) {
+		j := next[key]
+		old, ok := d.jobs[key]
+		var err error
+		switch {
+		case !ok:
+			d.saveJob(j)
+			j.id, err = d.scheduler.Add(d.schedulerJob(j))
+			summary.added++
+			d.logger.Info("job added", "job", j.name, "schedule", j.schedule(), "command", j.entry.Command)
+		case !old.entry.Expression.Equivalent(j.entry.Expression):
+			j.id = old.id
+			d.saveJob(j)
+			err = d.scheduler.Update(j.id, d.schedulerJob(j))
+			summary.rescheduled++
+			d.logger.Info("job rescheduled", "job", j.name, "from", old.schedule(), "to", j.schedule())
+		case old.entry.Command != j.entry.Command || !maps.Equal(old.env, j.env):
+			j.id = old.id
+			d.saveJob(j)
+			err = d.scheduler.Update(j.id, d.schedulerJob(j))
+			summary.updated++
+			d.logger.Info("job updated", "job", j.name, "command", j.entry.Command)
+		case old.name != j.name:
+			// The entry only moved to another line.
+			j.id = old.id
+			err = d.scheduler.Update(j.id, d.schedulerJob(j))
+			summary.unchanged++
+		default:
+			j.id = old.id
+			summary.unchanged++
+		}
+
+		if err != nil {
+			d.logger.Error("job not scheduled", "job", j.name, "err", err)
+			delete(next, key)
+		} else {
+			next[key] = j
+		}
+	}
+
+	d.jobs = next
+	d.logger.Info("crontab loaded", "jobs", len(d.jobs), "changes", summary.String())
+}
+
+func (d *Daemon) schedulerJob(j job) scheduler.Job {
+	res := scheduler.Job{Name: j.name, Schedule: j.entry.Expression, Run: d.runFunc(j), StartingDeadline: d.config.StartingDeadline}
+	if d.keepsState(j) {
+		res.CatchUp = d.config.CatchUp
+	}
+	return res
+}
+
+// keepsState reports whether the last runs of the job are kept in the
+// store, see Config.Store.
+func (d *Daemon) keepsState(j job) bool {
+	return d.config.Store != nil && j.entry.ID != ""
+}
+
+// saveJob keeps the definition of the job in the store, which has to know
+// a job before its last run can be set.
+func (d *Daemon) saveJob(j job) {
+	if !d.keepsState(j) {
+		return
+	}
+	expression := j.entry.Expression.Expression() + " " + j.entry.Command
+	err := d.config.Store.SaveJob(store.JobDefinition{Name: j.name, Expression: expression})
+	if err != nil {
+		d.logger.Error("job state not saved", "job", j.name, "err", err)
+	}
+}
+
+func (d *Daemon) forgetJob(j job) {
+	if !d.keepsState(j) {
+		return
+	}
+	err := d.config.Store.DeleteJob(j.name)
+	if err != nil && !errors.Is(err, store.ErrJobNotFound) {
+		d.logger.Error("job state not deleted", "job", j.name, "err", err)
+	}
+}
+
+func (j job) schedule() string {
+	return j.entry.Expression.Canonical()
+}
+
+func sortedKeys(jobs map[string]job) []string {
+	keys := []string{}
+	for key := range jobs {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,105 @@
+//go:build unix
+
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"cron_expression_parser/scheduler"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func entriesByName(d *Daemon) map[string]scheduler.Entry {
+	res := map[string]scheduler.Entry{}
+	for _, entry := range d.Entries() {
+		res[entry.Name] = entry
+	}
+	return res
+}
+
+func TestShouldApplyOnlyChangedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crontab")
+	writeCrontab(t, path, "0 * * * * keep.sh\n0 1 * * * move.sh\n0 2 * * * drop.sh\n# id: backup\n0 3 * * * backup.sh\n")
+
+	var logs bytes.Buffer
+	d := newDaemon(path)
+	d.logger = slog.New(slog.NewTextHandler(&logs, nil))
+	d.scheduler.Start()
+	defer d.scheduler.Stop(context.Background())
+	d.Reload()
+	before := entriesByName(d)
+
+	writeCrontab(t, path, "0 * * * * keep.sh\n30 1 * * * move.sh\n# id: backup\n0 4 * * * backup.sh --full\n0 5 * * * new.sh\n")
+	logs.Reset()
+	err := d.Reload()
+	if err != nil {
+		t.Fatalf("Should reload; %s", err)
+	}
+	after := entriesByName(d)
+
+	if after[path+":1"].ID != before[path+":1"].ID || !after[path+":1"].Next.Equal(before[path+":1"].Next) {
+		t.Fatalf("Should keep unchanged job, before: %v, after: %v", before, after)
+	}
+
+	if after[path+":2"].ID != before[path+":2"].ID || after[path+":2"].Next.Minute() != 30 {
+		t.Fatalf("Should reschedule job with the same command, actual: %v", after[path+":2"])
+	}
+
+	if after[path+":backup"].ID != before[path+":backup"].ID || after[path+":backup"].Next.Hour() != 4 {
+		t.Fatalf("Should reschedule job with the same id, actual: %v", after[path+":backup"])
+	}
+
+	if _, ok := after[path+":3"]; ok || len(after) != 4 {
+		t.Fatalf("Should remove dropped job, actual: %v", after)
+	}
+
+	for _, expected := range []string{
+		`msg="job removed" job=` + path + `:3 schedule="0 2 * * *" command=drop.sh`,
+		`msg="job added" job=` + path + `:5 schedule="0 5 * * *" command=new.sh`,
+		`msg="job rescheduled" job=` + path + `:2 from="0 1 * * *" to="30 1 * * *"`,
+		`changes="1 added, 1 removed, 2 rescheduled, 0 updated, 1 unchanged"`,
+	} {
+		if !strings.Contains(logs.String(), expected) {
+			t.Fatalf("Should log %q, actual:\n%s", expected, logs.String())
+		}
+	}
+}
+
+func TestShouldUpdateJobWhenEnvironmentChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crontab")
+	writeCrontab(t, path, "MAILTO=a@example.com\n0 * * * * report.sh\n")
+
+	var logs bytes.Buffer
+	d := newDaemon(path)
+	d.logger = slog.New(slog.NewTextHandler(&logs, nil))
+	d.Reload()
+
+	writeCrontab(t, path, "MAILTO=b@example.com\n0 * * * * report.sh\n")
+	d.Reload()
+
+	if !strings.Contains(logs.String(), `changes="0 added, 0 removed, 0 rescheduled, 1 updated, 0 unchanged"`) {
+		t.Fatalf("Should update job, actual:\n%s", logs.String())
+	}
+}
+
+func TestShouldRescheduleWhenDayFieldBecomesUnrestricted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crontab")
+	writeCrontab(t, path, "0 0 1-31/2 * 0-6/2 job.sh\n")
+
+	var logs bytes.Buffer
+	d := newDaemon(path)
+	d.logger = slog.New(slog.NewTextHandler(&logs, nil))
+	d.Reload()
+
+	// Same values, but odd days and every other weekday instead of either.
+	writeCrontab(t, path, "0 0 */2 * 0-6/2 job.sh\n")
+	logs.Reset()
+	d.Reload()
+
+	if !strings.Contains(logs.String(), `msg="job rescheduled"`) {
+		t.Fatalf("Should reschedule job, actual:\n%s", logs.String())
+	}
+}
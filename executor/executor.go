@@ -0,0 +1,247 @@
+// Package executor runs the command of a cron expression the way cron does:
+// through a shell, with the crontab environment and with everything after
+// the first unescaped % passed on stdin.
+package executor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultShell     = "/bin/sh"
+	DefaultPath      = "/usr/bin:/bin"
+	DefaultMaxOutput = 1 << 20
+	// DefaultKillDelay is how long the output of a killed process is still
+	// read before Run gives up on processes that keep its pipes open.
+	DefaultKillDelay = 5 * time.Second
+)
+
+type Config struct {
+	// Shell runs the commands as `Shell -c command`. It defaults to SHELL
+	// from Env and then to DefaultShell, like in cron.
+	Shell string
+	// Env is the crontab environment, e.g. crontab.File.Env. Commands do
+	// not inherit the environment of the current process; PATH, SHELL,
+	// HOME and LOGNAME are set to cron defaults when missing.
+	Env map[string]string
+	// Dir is the working directory, HOME from Env when empty.
+	Dir string
+	// Timeout kills the process group of a run that takes longer. Zero
+	// means no timeout.
+	Timeout time.Duration
+	// MaxOutput limits the captured stdout and stderr, the rest is
+	// discarded. Zero means DefaultMaxOutput, negative means no limit.
+	MaxOutput int
+	KillDelay time.Duration
+}
+
+// Result is the outcome of one run.
+type Result struct {
+	Command    string
+	Output     []byte
+	Truncated  bool
+	ExitCode   int
+	TimedOut   bool
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Err is set when the command could not be started or did not exit
+	// cleanly, including a non-zero exit status.
+	Err error
+}
+
+func (r Result) Success() bool {
+	return r.Err == nil
+}
+
+type Executor struct {
+	config Config
+}
+
+func New(config Config) *Executor {
+	if config.MaxOutput == 0 {
+		config.MaxOutput = DefaultMaxOutput
+	}
+	if config.KillDelay == 0 {
+		config.KillDelay = DefaultKillDelay
+	}
+	return &Executor{config: config}
+}
+
+// Run executes the command and waits for it. Cancelling ctx or reaching the
+// timeout kills the whole process group of the command.
+func (e *Executor) Run(ctx context.Context, command string) Result {
+	line, stdin := SplitCommand(command)
+	result := Result{Command: line, StartedAt: time.Now()}
+
+	runCtx := ctx
+	if e.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, e.config.Timeout)
+		defer cancel()
+	}
+
+	env := e.environment()
+	output := &limitedBuffer{limit: e.config.MaxOutput}
+	cmd := exec.CommandContext(runCtx, e.shell(env), "-c", line)
+	cmd.Env = envList(env)
+	cmd.Dir = e.config.Dir
+	if cmd.Dir == "" {
+		cmd.Dir = env["HOME"]
+	}
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.WaitDelay = e.config.KillDelay
+	setProcessGroup(cmd)
+	cmd.Cancel = func() error {
+		return killProcessGroup(cmd)
+	}
+
+	err := cmd.Run()
+	result.FinishedAt = time.Now()
+	result.Output, result.Truncated = output.Bytes(), output.truncated
+	result.ExitCode = exitCode(cmd, err)
+	result.TimedOut = errors.Is(runCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+	switch {
+	case result.TimedOut:
+		result.Err = &TimeoutError{Timeout: e.config.Timeout}
+	case err != nil && ctx.Err() != nil:
+		result.Err = ctx.Err()
+	default:
+		result.Err = err
+	}
+	return result
+}
+
+// Func returns a function that can be used as scheduler.Job.Run, reporting
+// every result to onResult when it is not nil.
+func (e *Executor) Func(command string, onResult func(Result)) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		result := e.Run(ctx, command)
+		if onResult != nil {
+			onResult(result)
+		}
+	}
+}
+
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return "Command timed out after " + e.Timeout.String()
+}
+
+func (e *Executor) shell(env map[string]string) string {
+	if e.config.Shell != "" {
+		return e.config.Shell
+	}
+	return env["SHELL"]
+}
+
+func (e *Executor) environment() map[string]string {
+	env := map[string]string{
+		"SHELL": DefaultShell,
+		"PATH":  DefaultPath,
+		"HOME":  "/",
+	}
+	for key, value := range e.config.Env {
+		env[key] = value
+	}
+	if _, ok := env["LOGNAME"]; !ok {
+		if user, ok := env["USER"]; ok {
+			env["LOGNAME"] = user
+		}
+	}
+	return env
+}
+
+func envList(env map[string]string) []string {
+	res := []string{}
+	for key, value := range env {
+		res = append(res, key+"="+value)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func exitCode(cmd *exec.Cmd, err error) int {
+	if cmd.ProcessState != nil {
+		return cmd.ProcessState.ExitCode()
+	}
+	if err != nil {
+		return -1
+	}
+	return 0
+}
+
+// SplitCommand splits a crontab command at the first unescaped % into the
+// command line and its stdin. Further % in the stdin part become newlines
+// and \% stands for a literal % in both parts.
+func SplitCommand(command string) (string, string) {
+	var line, stdin strings.Builder
+	current := &line
+	inStdin := false
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		if c == '\\' && i+1 < len(command) && command[i+1] == '%' {
+			current.WriteByte('%')
+			i++
+			continue
+		}
+		if c == '%' {
+			if inStdin {
+				current.WriteByte('\n')
+			} else {
+				inStdin = true
+				current = &stdin
+			}
+			continue
+		}
+		current.WriteByte(c)
+	}
+	if inStdin && stdin.Len() > 0 {
+		stdin.WriteByte('\n')
+	}
+	return line.String(), stdin.String()
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the
+// rest, so a chatty command never blocks on a full pipe.
+type limitedBuffer struct {
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.limit < 0 {
+		return b.buf.Write(p)
+	}
+	room := b.limit - b.buf.Len()
+	if len(p) > room {
+		b.truncated = true
+		b.buf.Write(p[:max(room, 0)])
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return bytes.Clone(b.buf.Bytes())
+}
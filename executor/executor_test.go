@@ -0,0 +1,123 @@
+//go:build unix
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShouldSplitCommandAtPercent(t *testing.T) {
+	tests := []struct {
+		command string
+		line    string
+		stdin   string
+	}{
+		{"echo hello", "echo hello", ""},
+		{"cat%line one%line two", "cat", "line one\nline two\n"},
+		{`date +\%Y-\%m`, "date +%Y-%m", ""},
+		{`mail -s "100\% done" root%body`, `mail -s "100% done" root`, "body\n"},
+		{"cat%", "cat", ""},
+	}
+
+	for _, test := range tests {
+		line, stdin := SplitCommand(test.command)
+		if line != test.line || stdin != test.stdin {
+			t.Errorf("Should split %q into %q and %q, actual: %q and %q", test.command, test.line, test.stdin, line, stdin)
+		}
+	}
+}
+
+func TestShouldRunCommandWithCrontabEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	e := New(Config{Env: map[string]string{"GREETING": "hello", "HOME": dir}})
+
+	result := e.Run(context.Background(), `echo "$GREETING $LOGNAME"; pwd; echo $PATH; echo oops >&2`)
+	if !result.Success() || result.ExitCode != 0 {
+		t.Fatalf("Should run command; %v", result.Err)
+	}
+
+	resolved, _ := filepath.EvalSymlinks(dir)
+	expected := "hello \n" + resolved + "\n" + DefaultPath + "\noops\n"
+	if string(result.Output) != expected {
+		t.Fatalf("Should capture output, expected: %q, actual: %q", expected, result.Output)
+	}
+}
+
+func TestShouldPassStdinAfterPercent(t *testing.T) {
+	e := New(Config{})
+
+	result := e.Run(context.Background(), "tr a-z A-Z%first%second")
+	if string(result.Output) != "FIRST\nSECOND\n" {
+		t.Fatalf("Should pass stdin, actual: %q", result.Output)
+	}
+}
+
+func TestShouldReportExitCode(t *testing.T) {
+	e := New(Config{})
+
+	result := e.Run(context.Background(), "exit 3")
+	if result.Success() || result.ExitCode != 3 {
+		t.Fatalf("Should report exit code 3, actual: %d; %v", result.ExitCode, result.Err)
+	}
+}
+
+func TestShouldUseConfiguredShell(t *testing.T) {
+	dir := t.TempDir()
+	shell := filepath.Join(dir, "shell")
+	os.WriteFile(shell, []byte("#!/bin/sh\necho \"shell got $2\"\n"), 0o755)
+
+	e := New(Config{Env: map[string]string{"SHELL": shell}})
+	result := e.Run(context.Background(), "some command")
+	if string(result.Output) != "shell got some command\n" {
+		t.Fatalf("Should use SHELL from the environment, actual: %q; %v", result.Output, result.Err)
+	}
+}
+
+func TestShouldLimitCapturedOutput(t *testing.T) {
+	e := New(Config{MaxOutput: 10})
+
+	result := e.Run(context.Background(), "head -c 100000 /dev/zero | tr '\\0' x")
+	if !result.Success() {
+		t.Fatalf("Should drain output beyond the limit; %v", result.Err)
+	}
+
+	if len(result.Output) != 10 || !result.Truncated {
+		t.Fatalf("Should keep only 10 bytes, actual: %d", len(result.Output))
+	}
+}
+
+func TestShouldKillProcessGroupOnTimeout(t *testing.T) {
+	e := New(Config{Timeout: 100 * time.Millisecond, KillDelay: time.Second})
+
+	start := time.Now()
+	result := e.Run(context.Background(), "sleep 30 & sleep 30; echo done")
+	if time.Since(start) > 5*time.Second {
+		t.Fatalf("Should kill the whole process group, took %s", time.Since(start))
+	}
+
+	var timeoutErr *TimeoutError
+	if !result.TimedOut || !errors.As(result.Err, &timeoutErr) {
+		t.Fatalf("Should report timeout, actual: %v", result.Err)
+	}
+
+	if strings.Contains(string(result.Output), "done") {
+		t.Fatalf("Should not finish the command, actual: %q", result.Output)
+	}
+}
+
+func TestShouldStopRunWhenContextIsCancelled(t *testing.T) {
+	e := New(Config{})
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	result := e.Run(ctx, "sleep 30")
+	if result.TimedOut || !errors.Is(result.Err, context.Canceled) {
+		t.Fatalf("Should report cancellation, actual: %v", result.Err)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"cron_expression_parser/ical"
+	"flag"
+	"fmt"
+	"time"
+)
+
+// icalOptions are the flags of convert that only apply to --to ical.
+type icalOptions struct {
+	tz       string
+	from     string
+	window   time.Duration
+	duration time.Duration
+	summary  string
+	crontab  string
+}
+
+func icalFlags(flags *flag.FlagSet) *icalOptions {
+	options := &icalOptions{}
+	flags.StringVar(&options.tz, "tz", "UTC", "ical: time zone of the schedule, e.g. Europe/Warsaw")
+	flags.StringVar(&options.from, "from", "", "ical: first occurrence at or after this time, e.g. 2026-11-01 (default now)")
+	flags.DurationVar(&options.window, "window", ical.DefaultWindow, "ical: how far runs are listed when there is no exact RRULE")
+	flags.DurationVar(&options.duration, "duration", 15*time.Minute, "ical: length of every occurrence, 0 for none")
+	flags.StringVar(&options.summary, "summary", "", "ical: title of the event (default command or expression)")
+	flags.StringVar(&options.crontab, "crontab", "", "ical: export every entry of the given crontab file, - for stdin")
+	return options
+}
+
+// convertICal writes the expression, or the entries of a crontab, as an
+// .ics calendar.
+func (c *cli) convertICal(flags *flag.FlagSet, args []string, options *icalOptions, dayPolicy string) int {
+	location, err := time.LoadLocation(options.tz)
+	if err != nil || options.tz == "" || options.tz == "Local" {
+		return usageError(flags, "Unknown time zone %q", options.tz)
+	}
+
+	start := time.Now().In(location)
+	if options.from != "" {
+		start, err = parseFrom(options.from, location)
+		if err != nil {
+			return usageError(flags, "%s", err)
+		}
+	}
+
+	if options.window <= 0 {
+		return usageError(flags, "-window should be positive")
+	}
+	if options.duration < 0 {
+		return usageError(flags, "-duration should not be negative")
+	}
+
+	calendar := &ical.Calendar{Start: start.In(location), Window: options.window}
+	if options.crontab != "" {
+		file, code, ok := c.crontabArg(options.crontab)
+		if !ok {
+			return code
+		}
+
+		for _, entry := range file.Entries() {
+			calendar.Events = append(calendar.Events, ical.Event{Schedule: entry.Expression, UID: entry.ID, Summary: options.summary, Duration: options.duration})
+		}
+	} else {
+		schedule, code, ok := c.expressionArg(flags, args, dayPolicy)
+		if !ok {
+			return code
+		}
+		calendar.Events = []ical.Event{{Schedule: schedule, Summary: options.summary, Duration: options.duration}}
+	}
+
+	for _, event := range calendar.Events {
+		if _, err := ical.RRule(event.Schedule); err != nil {
+			fmt.Fprintf(c.stderr, "warning: %q: %s, listing its runs within %s as RDATEs\n", event.Schedule.Expression(), err, options.window)
+		}
+	}
+
+	err = calendar.Write(c.stdout)
+	if err != nil {
+		return c.fail(err)
+	}
+	return exitOK
+}
@@ -0,0 +1,352 @@
+package ical
+
+import (
+	"cron_expression_parser/parser"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// frequencies of RRULE from the finest to the coarsest. Cron cannot fire
+// more often than every minute, so SECONDLY is not supported.
+var frequencies = []string{"MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+// byDay is an entry of BYDAY, e.g. "MO" or "-1FR".
+var byDay = regexp.MustCompile(`^([+-]?\d{1,2})?(SU|MO|TU|WE|TH|FR|SA)$`)
+
+// rule is a parsed RRULE value.
+type rule struct {
+	freq     int
+	interval int
+	parts    map[string]string
+}
+
+// ToCron returns the schedule firing at the same times as the RRULE value,
+// e.g. "FREQ=WEEKLY;BYDAY=MO,FR;BYHOUR=9;BYMINUTE=0", or an error explaining
+// why cron cannot express it. The time of the fields the rule does not set,
+// e.g. the minute of "FREQ=HOURLY", comes from dtstart as in RFC 5545; a zero
+// dtstart means the rule has to set them. The schedule fires in the time
+// zone of dtstart.
+//
+// "FREQ=MONTHLY;BYDAY=1MO" (the first Monday) is converted to "* * 1-7 * 1"
+// with DayPolicyAnd, as are rules with both BYMONTHDAY and BYDAY.
+func ToCron(rrule string, dtstart time.Time) (*parser.Schedule, error) {
+	r, err := parseRule(rrule)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{"COUNT", "UNTIL"} {
+		if _, ok := r.parts[name]; ok {
+			return nil, fmt.Errorf("%s cannot be expressed, cron expressions do not end", name)
+		}
+	}
+	for _, name := range []string{"BYSETPOS", "BYYEARDAY", "BYWEEKNO"} {
+		if _, ok := r.parts[name]; ok {
+			return nil, fmt.Errorf("%s cannot be expressed, cron has no field for it", name)
+		}
+	}
+	// Days, weeks and years do not divide the next larger unit evenly, so
+	// steps over them do not repeat within any cron field.
+	if r.interval > 1 && (r.is("DAILY") || r.is("WEEKLY") || r.is("YEARLY")) {
+		return nil, fmt.Errorf("INTERVAL=%d with FREQ=%s cannot be expressed, cron has no notion of every %s %s", r.interval, frequencies[r.freq], ordinal(r.interval), unitOf(frequencies[r.freq]))
+	}
+	if second, ok := r.parts["BYSECOND"]; ok && second != "0" {
+		return nil, fmt.Errorf("BYSECOND=%s cannot be expressed, cron fires at the start of a minute", second)
+	}
+	if !dtstart.IsZero() && dtstart.Second() != 0 {
+		if _, ok := r.parts["BYSECOND"]; !ok {
+			return nil, errors.New("DTSTART has seconds, cron fires at the start of a minute")
+		}
+	}
+
+	fields := make([]string, 5)
+	fields[0], err = r.timeField("BYMINUTE", "MINUTELY", 60, dtstart, time.Time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	fields[1], err = r.timeField("BYHOUR", "HOURLY", 24, dtstart, time.Time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	var policy parser.DayPolicy
+	fields[2], fields[4], policy, err = r.dayFields(dtstart)
+	if err != nil {
+		return nil, err
+	}
+	fields[3], err = r.monthField(dtstart)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := parser.ParseTimeFields(strings.Join(fields, " "))
+	if err != nil {
+		return nil, fmt.Errorf("Cron expression %q is invalid: %w", strings.Join(fields, " "), err)
+	}
+	// Parsed again so the expression is written in its shortest form, with
+	// the policy applied so a full day field becomes "*" only where that
+	// keeps the meaning. AND is only needed while both day fields are still
+	// restricted.
+	schedule, err = parser.ParseTimeFields(schedule.WithDayPolicy(policy).Canonical())
+	if err != nil {
+		return nil, err
+	}
+	if !schedule.EitherDayMatches() {
+		policy = parser.DayPolicyOr
+	}
+	return schedule.WithDayPolicy(policy), nil
+}
+
+func parseRule(rrule string) (*rule, error) {
+	rrule = strings.TrimPrefix(strings.TrimSpace(rrule), "RRULE:")
+	r := &rule{interval: 1, parts: map[string]string{}}
+	for _, part := range strings.Split(rrule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if !ok || name == "" || value == "" {
+			return nil, fmt.Errorf("Part %q should be NAME=VALUE", part)
+		}
+		if _, ok := r.parts[name]; ok {
+			return nil, fmt.Errorf("Part %s is given twice", name)
+		}
+		r.parts[name] = strings.ToUpper(strings.TrimSpace(value))
+	}
+
+	freq, ok := r.parts["FREQ"]
+	if !ok {
+		return nil, errors.New("FREQ is required")
+	}
+	r.freq = -1
+	for i, name := range frequencies {
+		if name == freq {
+			r.freq = i
+		}
+	}
+	if freq == "SECONDLY" {
+		return nil, errors.New("FREQ=SECONDLY cannot be expressed, cron fires at most once a minute")
+	}
+	if r.freq < 0 {
+		return nil, fmt.Errorf("Unknown FREQ %q", freq)
+	}
+
+	for name, value := range r.parts {
+		switch name {
+		case "FREQ", "WKST", "COUNT", "UNTIL", "BYSECOND", "BYMINUTE", "BYHOUR", "BYDAY",
+			"BYMONTHDAY", "BYYEARDAY", "BYWEEKNO", "BYMONTH", "BYSETPOS":
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("INTERVAL should be a positive number, got %q", value)
+			}
+			r.interval = interval
+		default:
+			return nil, fmt.Errorf("Unknown part %s", name)
+		}
+	}
+	return r, nil
+}
+
+// is reports whether the frequency of the rule is freq.
+func (r *rule) is(freq string) bool {
+	return frequencies[r.freq] == freq
+}
+
+// finerThan reports whether the rule repeats more often than freq.
+func (r *rule) finerThan(freq string) bool {
+	for i, name := range frequencies {
+		if name == freq {
+			return r.freq < i
+		}
+	}
+	return false
+}
+
+// timeField converts BYMINUTE or BYHOUR. Without it the field is every
+// value when the rule repeats that often, stepped by INTERVAL when it
+// divides the range evenly, and the value of dtstart otherwise.
+func (r *rule) timeField(part, freq string, size int, dtstart time.Time, valueOf func(time.Time) int) (string, error) {
+	values, ok := r.parts[part]
+	if r.is(freq) && r.interval > 1 {
+		if ok {
+			return "", fmt.Errorf("INTERVAL=%d with %s cannot be expressed, cron cannot skip some of the values", r.interval, part)
+		}
+		if size%r.interval != 0 {
+			return "", fmt.Errorf("INTERVAL=%d with FREQ=%s cannot be expressed, %d is not a multiple of it", r.interval, freq, size)
+		}
+		if dtstart.IsZero() {
+			return "", fmt.Errorf("INTERVAL=%d with FREQ=%s needs DTSTART to know where the steps start", r.interval, freq)
+		}
+		return fmt.Sprintf("%d-%d/%d", valueOf(dtstart)%r.interval, size-1, r.interval), nil
+	}
+
+	if ok {
+		return numberList(part, values)
+	}
+	if r.finerThan(freq) || r.is(freq) {
+		return "*", nil
+	}
+	if dtstart.IsZero() {
+		return "", fmt.Errorf("FREQ=%s without %s needs DTSTART to know the value", frequencies[r.freq], part)
+	}
+	return strconv.Itoa(valueOf(dtstart)), nil
+}
+
+// dayFields converts BYMONTHDAY and BYDAY to day of month and day of week.
+// Both narrow the days down in RRULE, so when both are restricted the
+// schedule needs DayPolicyAnd.
+func (r *rule) dayFields(dtstart time.Time) (daysOfMonth, daysOfWeek string, policy parser.DayPolicy, err error) {
+	monthDays, hasMonthDays := r.parts["BYMONTHDAY"]
+	days, hasDays := r.parts["BYDAY"]
+
+	daysOfMonth, daysOfWeek = "*", "*"
+	if hasMonthDays {
+		if r.is("WEEKLY") {
+			return "", "", policy, errors.New("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+		}
+		for _, number := range strings.Split(monthDays, ",") {
+			if _, err := strconv.Atoi(number); err == nil && strings.HasPrefix(number, "-") {
+				return "", "", policy, errors.New("Negative BYMONTHDAY cannot be expressed, cron cannot count days from the end of the month")
+			}
+		}
+		daysOfMonth, err = numberList("BYMONTHDAY", monthDays)
+		if err != nil {
+			return "", "", policy, err
+		}
+	}
+
+	if hasDays {
+		weekdays, nth, err := r.parseDays(days)
+		if err != nil {
+			return "", "", policy, err
+		}
+		daysOfWeek = strings.Join(weekdays, ",")
+		if nth > 0 {
+			if hasMonthDays {
+				return "", "", policy, errors.New("BYDAY with a number and BYMONTHDAY cannot be expressed together")
+			}
+			// The n-th weekday of a month is the one within its n-th seven days.
+			daysOfMonth = fmt.Sprintf("%d-%d", (nth-1)*7+1, nth*7)
+		}
+	}
+
+	if daysOfMonth != "*" && daysOfWeek != "*" {
+		policy = parser.DayPolicyAnd
+	}
+	if hasMonthDays || hasDays || r.finerThan("WEEKLY") {
+		return daysOfMonth, daysOfWeek, policy, nil
+	}
+
+	if dtstart.IsZero() {
+		return "", "", policy, fmt.Errorf("FREQ=%s without BYDAY or BYMONTHDAY needs DTSTART to know the day", frequencies[r.freq])
+	}
+	if r.is("WEEKLY") {
+		return "*", strconv.Itoa(int(dtstart.Weekday())), policy, nil
+	}
+	return strconv.Itoa(dtstart.Day()), "*", policy, nil
+}
+
+// parseDays returns the cron days of week of BYDAY and the number they are
+// prefixed with, 0 when there is none.
+func (r *rule) parseDays(days string) (values []string, nth int, err error) {
+	for i, day := range strings.Split(days, ",") {
+		match := byDay.FindStringSubmatch(day)
+		if match == nil {
+			return nil, 0, fmt.Errorf("BYDAY value %q should be a weekday like MO or 1MO", day)
+		}
+
+		n := 0
+		if match[1] != "" {
+			n, _ = strconv.Atoi(match[1])
+			if n < 0 {
+				return nil, 0, fmt.Errorf("BYDAY=%s cannot be expressed, cron cannot count weekdays from the end of the month", day)
+			}
+			if n < 1 || n > 4 {
+				return nil, 0, fmt.Errorf("BYDAY=%s cannot be expressed, cron can only select the first four weekdays of a month", day)
+			}
+			if !r.is("MONTHLY") && !(r.is("YEARLY") && r.parts["BYMONTH"] != "") {
+				return nil, 0, fmt.Errorf("BYDAY=%s cannot be expressed, cron can only count weekdays within a month", day)
+			}
+		}
+		if i > 0 && n != nth {
+			return nil, 0, fmt.Errorf("BYDAY=%s cannot be expressed, all weekdays should have the same number", days)
+		}
+		nth = n
+
+		for value, name := range weekdays {
+			if name == match[2] {
+				values = append(values, strconv.Itoa(value))
+			}
+		}
+	}
+	return values, nth, nil
+}
+
+// monthField converts BYMONTH. Monthly rules may step by INTERVAL when it
+// divides the year evenly.
+func (r *rule) monthField(dtstart time.Time) (string, error) {
+	months, ok := r.parts["BYMONTH"]
+	if r.is("MONTHLY") && r.interval > 1 {
+		if ok {
+			return "", fmt.Errorf("INTERVAL=%d with BYMONTH cannot be expressed, cron cannot skip some of the months", r.interval)
+		}
+		if 12%r.interval != 0 {
+			return "", fmt.Errorf("INTERVAL=%d with FREQ=MONTHLY cannot be expressed, 12 is not a multiple of it", r.interval)
+		}
+		if dtstart.IsZero() {
+			return "", fmt.Errorf("INTERVAL=%d with FREQ=MONTHLY needs DTSTART to know where the steps start", r.interval)
+		}
+		return fmt.Sprintf("%d-12/%d", (int(dtstart.Month())-1)%r.interval+1, r.interval), nil
+	}
+
+	if ok {
+		return numberList("BYMONTH", months)
+	}
+	// Days without a month are expanded over the whole year.
+	_, hasMonthDays := r.parts["BYMONTHDAY"]
+	_, hasDays := r.parts["BYDAY"]
+	if !r.is("YEARLY") || hasMonthDays || hasDays {
+		return "*", nil
+	}
+	if dtstart.IsZero() {
+		return "", errors.New("FREQ=YEARLY without BYMONTH needs DTSTART to know the month")
+	}
+	return strconv.Itoa(int(dtstart.Month())), nil
+}
+
+// numberList checks that the value of a BY part is a list of non-negative
+// numbers, which is a cron list as it is. The parser checks the ranges.
+func numberList(part, value string) (string, error) {
+	for _, number := range strings.Split(value, ",") {
+		if _, err := strconv.Atoi(number); err != nil || strings.HasPrefix(number, "-") || strings.HasPrefix(number, "+") {
+			return "", fmt.Errorf("%s value %q should be a non-negative number", part, number)
+		}
+	}
+	return value, nil
+}
+
+func ordinal(n int) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+		return fmt.Sprintf("%dth", n)
+	case n%10 == 1:
+		return fmt.Sprintf("%dst", n)
+	case n%10 == 2:
+		return fmt.Sprintf("%dnd", n)
+	case n%10 == 3:
+		return fmt.Sprintf("%drd", n)
+	}
+	return fmt.Sprintf("%dth", n)
+}
+
+func unitOf(freq string) string {
+	switch freq {
+	case "DAILY":
+		return "day"
+	case "WEEKLY":
+		return "week"
+	}
+	return "year"
+}
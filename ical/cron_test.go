@@ -0,0 +1,146 @@
+package ical
+
+import (
+	"cron_expression_parser/parser"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var allMonthDays = func() string {
+	days := []string{}
+	for day := 1; day <= 31; day++ {
+		days = append(days, strconv.Itoa(day))
+	}
+	return strings.Join(days, ",")
+}()
+
+func TestShouldConvertRRuleToCron(t *testing.T) {
+	dtstart := time.Date(2026, time.November, 4, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		rrule    string
+		expected string
+	}{
+		{"FREQ=DAILY;BYHOUR=2;BYMINUTE=30", "30 2 * * *"},
+		{"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0", "0 9 * * 1-5"},
+		{"FREQ=MINUTELY", "* * * * *"},
+		{"FREQ=MINUTELY;INTERVAL=15;BYHOUR=9,10,11", "*/15 9-11 * * *"},
+		{"FREQ=HOURLY;INTERVAL=6", "30 3-21/6 * * *"},
+		{"FREQ=DAILY", "30 9 * * *"},
+		{"FREQ=WEEKLY", "30 9 * * 3"},
+		{"FREQ=MONTHLY", "30 9 4 * *"},
+		{"FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1;BYHOUR=0;BYMINUTE=0", "0 0 1 2-11/3 *"},
+		{"FREQ=YEARLY", "30 9 4 11 *"},
+		{"FREQ=YEARLY;BYMONTHDAY=1", "30 9 1 * *"},
+		{"freq=yearly;bymonth=1,7;bymonthday=1,15", "30 9 1,15 1,7 *"},
+		{"FREQ=MONTHLY;BYMONTHDAY=" + allMonthDays + ";BYDAY=MO;BYHOUR=9;BYMINUTE=0", "0 9 * * 1"},
+		{"FREQ=WEEKLY;BYDAY=SU,MO,TU,WE,TH,FR,SA;BYHOUR=9;BYMINUTE=0", "0 9 * * *"},
+	}
+
+	for _, test := range tests {
+		schedule, err := ToCron(test.rrule, dtstart)
+		if err != nil {
+			t.Errorf("Should convert %q; %s", test.rrule, err)
+			continue
+		}
+		if schedule.Expression() != test.expected {
+			t.Errorf("Should convert %q, expected: %s, actual: %s", test.rrule, test.expected, schedule.Expression())
+		}
+		if schedule.DayPolicy() != parser.DayPolicyOr {
+			t.Errorf("Should keep OR day policy for %q", test.rrule)
+		}
+	}
+}
+
+func TestShouldConvertNarrowedDaysWithAndPolicy(t *testing.T) {
+	tests := map[string]string{
+		"FREQ=MONTHLY;BYDAY=1MO;BYHOUR=3;BYMINUTE=0":              "0 3 1-7 * 1",
+		"FREQ=YEARLY;BYMONTH=5;BYDAY=2SU;BYHOUR=9;BYMINUTE=0":     "0 9 8-14 5 0",
+		"FREQ=MONTHLY;BYMONTHDAY=13;BYDAY=FR;BYHOUR=0;BYMINUTE=0": "0 0 13 * 5",
+	}
+
+	for rrule, expected := range tests {
+		schedule, err := ToCron(rrule, time.Time{})
+		if err != nil {
+			t.Errorf("Should convert %q; %s", rrule, err)
+			continue
+		}
+		if schedule.Expression() != expected || schedule.DayPolicy() != parser.DayPolicyAnd {
+			t.Errorf("Should convert %q to %s with AND policy, actual: %s %v", rrule, expected, schedule.Expression(), schedule.DayPolicy())
+		}
+	}
+}
+
+func TestShouldExplainWhyRRuleCannotBeConverted(t *testing.T) {
+	dtstart := time.Date(2026, time.November, 4, 9, 30, 0, 0, time.UTC)
+	tests := map[string]string{
+		"INTERVAL=2;FREQ=WEEKLY":            "cron has no notion of every 2nd week",
+		"FREQ=DAILY;INTERVAL=3":             "cron has no notion of every 3rd day",
+		"FREQ=MINUTELY;INTERVAL=7":          "60 is not a multiple of it",
+		"FREQ=MONTHLY;INTERVAL=5":           "12 is not a multiple of it",
+		"FREQ=HOURLY;INTERVAL=2;BYHOUR=1,2": "cron cannot skip some of the values",
+		"FREQ=DAILY;COUNT=10":               "cron expressions do not end",
+		"FREQ=DAILY;UNTIL=20261231T000000Z": "cron expressions do not end",
+		"FREQ=MONTHLY;BYDAY=-1FR":           "from the end of the month",
+		"FREQ=MONTHLY;BYMONTHDAY=-1":        "from the end of the month",
+		"FREQ=MONTHLY;BYMONTHDAY=1,-1":      "from the end of the month",
+		"FREQ=MONTHLY;BYMONTHDAY=1-3":       "non-negative number",
+		"FREQ=MONTHLY;BYDAY=5MO":            "first four weekdays",
+		"FREQ=MONTHLY;BYDAY=1MO,2TU":        "same number",
+		"FREQ=YEARLY;BYDAY=20MO":            "first four weekdays",
+		"FREQ=YEARLY;BYDAY=1MO":             "only count weekdays within a month",
+		"FREQ=MONTHLY;BYDAY=MO;BYSETPOS=-1": "BYSETPOS cannot be expressed",
+		"FREQ=YEARLY;BYWEEKNO=20":           "BYWEEKNO cannot be expressed",
+		"FREQ=SECONDLY":                     "at most once a minute",
+		"FREQ=MINUTELY;BYSECOND=30":         "start of a minute",
+		"FREQ=FORTNIGHTLY":                  "Unknown FREQ",
+		"BYHOUR=9":                          "FREQ is required",
+		"FREQ=DAILY;FREQ=WEEKLY":            "given twice",
+		"FREQ=DAILY;BYHOUR=25":              "is invalid",
+		"FREQ=DAILY;BYHOUR=9am":             "non-negative number",
+		"FREQ=DAILY;X-NAME=1":               "Unknown part",
+		"FREQ=WEEKLY;BYMONTHDAY=1":          "cannot be used with FREQ=WEEKLY",
+	}
+
+	for rrule, reason := range tests {
+		_, err := ToCron(rrule, dtstart)
+		if err == nil || !strings.Contains(err.Error(), reason) {
+			t.Errorf("Should not convert %q because %s, actual: %v", rrule, reason, err)
+		}
+	}
+}
+
+func TestShouldNeedDTStartForMissingFields(t *testing.T) {
+	_, err := ToCron("FREQ=DAILY", time.Time{})
+	if err == nil || !strings.Contains(err.Error(), "needs DTSTART") {
+		t.Fatalf("Should need DTSTART for the time of day, actual: %v", err)
+	}
+
+	schedule, err := ToCron("FREQ=DAILY;BYHOUR=9;BYMINUTE=0", time.Time{})
+	if err != nil || schedule.Expression() != "0 9 * * *" {
+		t.Fatalf("Should not need DTSTART when the rule sets every field, actual: %v", err)
+	}
+}
+
+func TestShouldConvertExportedRRuleBack(t *testing.T) {
+	for _, input := range []string{"30 2 * * 1-5", "*/15 9-17 * * *", "0 0 1,15 1,7 *", "* * * * *", "0 3 1-7 * 1"} {
+		schedule, err := parser.ParseTimeFields(input)
+		if err != nil {
+			t.Fatalf("Should not return error with proper input; %s", err)
+		}
+		schedule = schedule.WithDayPolicy(parser.DayPolicyAnd)
+
+		rrule, err := RRule(schedule)
+		if err != nil {
+			t.Fatalf("Should build RRULE of %q; %s", input, err)
+		}
+		converted, err := ToCron(rrule, time.Time{})
+		if err != nil {
+			t.Fatalf("Should convert %q back; %s", rrule, err)
+		}
+		if converted.Canonical() != schedule.Canonical() {
+			t.Errorf("Should convert %q back to %q, actual: %q", rrule, schedule.Canonical(), converted.Canonical())
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// Package ical converts schedules to and from iCalendar (RFC 5545), so cron
+// jobs can be overlaid on a shared calendar.
+package ical
+
+import (
+	"bufio"
+	"cron_expression_parser/parser"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultWindow is how far runs are listed for schedules without an
+	// exact RRULE.
+	DefaultWindow = 30 * 24 * time.Hour
+	// MaxRDates bounds the runs listed in the window, so a schedule firing
+	// every minute does not produce a calendar nobody can import.
+	MaxRDates = 1000
+
+	prodID = "-//cron_expression_parser//EN"
+	// lineLength is the maximum length of a content line in octets.
+	lineLength = 75
+
+	dateTimeLayout = "20060102T150405"
+)
+
+// Event is a schedule exported as a recurring VEVENT.
+type Event struct {
+	Schedule *parser.Schedule
+	// UID identifies the event across exports, so calendars update it
+	// instead of adding a copy. Derived from the expression and command
+	// when empty.
+	UID string
+	// Summary is the title of the event, the command or the expression
+	// when empty.
+	Summary string
+	// Description defaults to the expression described in English.
+	Description string
+	// Duration is how long each occurrence lasts, zero for none.
+	Duration time.Duration
+}
+
+// Calendar is a VCALENDAR of events.
+type Calendar struct {
+	Events []Event
+	// Start is the time the first occurrence of every event is searched
+	// from. Its location is the time zone of the events: UTC or a named IANA
+	// zone such as Europe/Warsaw, written as a VTIMEZONE.
+	Start time.Time
+	// Window is how far after the first occurrence runs are listed as
+	// RDATEs for schedules without an exact RRULE, DefaultWindow when zero.
+	Window time.Duration
+	// Stamp is the DTSTAMP of every event, time.Now when zero.
+	Stamp time.Time
+}
+
+// Write writes the calendar as an .ics file. Every event gets an RRULE when
+// its schedule has an exact one, see RRule, and otherwise its runs within
+// the window as RDATEs.
+func (c *Calendar) Write(w io.Writer) error {
+	location := c.Start.Location()
+	if location == time.Local {
+		return errors.New("Start should be in UTC or a named time zone, not Local")
+	}
+
+	window := c.Window
+	if window == 0 {
+		window = DefaultWindow
+	}
+	stamp := c.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	lw := &lineWriter{w: bufio.NewWriter(w)}
+	lw.property("BEGIN", "VCALENDAR")
+	lw.property("VERSION", "2.0")
+	lw.property("PRODID", prodID)
+	lw.property("CALSCALE", "GREGORIAN")
+	if location != time.UTC {
+		// Events start from Start, but occurrences of the year before may
+		// still be shown, e.g. an RDATE in the window.
+		writeTimezone(lw, location, c.Start.Year()-1)
+	}
+	for _, event := range c.Events {
+		err := c.writeEvent(lw, event, window, stamp)
+		if err != nil {
+			return err
+		}
+	}
+	lw.property("END", "VCALENDAR")
+	return lw.flush()
+}
+
+func (c *Calendar) writeEvent(lw *lineWriter, event Event, window time.Duration, stamp time.Time) error {
+	schedule := event.Schedule
+	// Next only returns times after the given one, so a run exactly at
+	// Start is the first occurrence too.
+	first := schedule.Next(c.Start.Add(-time.Nanosecond))
+	if first.IsZero() {
+		return fmt.Errorf("Expression %q does not fire within the next five years", schedule.Expression())
+	}
+
+	uid := event.UID
+	if uid == "" {
+		sum := sha256.Sum256([]byte(schedule.Expression() + "\x00" + schedule.Command()))
+		uid = fmt.Sprintf("%x@cron_expression_parser", sum[:8])
+	}
+	summary := event.Summary
+	if summary == "" {
+		summary = schedule.Command()
+	}
+	if summary == "" {
+		summary = schedule.Expression()
+	}
+	description := event.Description
+	if description == "" {
+		description = schedule.Describe() + "\n\ncron: " + schedule.String()
+	}
+
+	lw.property("BEGIN", "VEVENT")
+	lw.property("UID", escapeText(uid))
+	lw.property("DTSTAMP", stamp.UTC().Format(dateTimeLayout)+"Z")
+	lw.dateTime("DTSTART", first)
+	if event.Duration > 0 {
+		lw.property("DURATION", formatDuration(event.Duration))
+	}
+
+	rrule, err := RRule(schedule)
+	if err == nil {
+		lw.property("RRULE", rrule)
+	} else {
+		runs := schedule.Between(first, first.Add(window))
+		if len(runs) > MaxRDates {
+			return fmt.Errorf("Expression %q fires %d times within the window, at most %d can be listed: %w", schedule.Expression(), len(runs), MaxRDates, err)
+		}
+		for _, run := range runs {
+			lw.dateTime("RDATE", run)
+		}
+	}
+
+	lw.property("SUMMARY", escapeText(summary))
+	lw.property("DESCRIPTION", escapeText(description))
+	lw.property("END", "VEVENT")
+	return nil
+}
+
+// lineWriter writes content lines, folded and terminated with CRLF. The
+// first error is kept and returned by flush.
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (lw *lineWriter) property(name, value string) {
+	lw.line(name + ":" + value)
+}
+
+// dateTime writes a DATE-TIME property in UTC or with the TZID of the
+// location of t.
+func (lw *lineWriter) dateTime(name string, t time.Time) {
+	if t.Location() == time.UTC {
+		lw.property(name, t.Format(dateTimeLayout)+"Z")
+		return
+	}
+	lw.property(name+";TZID="+t.Location().String(), t.Format(dateTimeLayout))
+}
+
+// line folds lines longer than lineLength octets with CRLF and a space,
+// without splitting UTF-8 sequences.
+func (lw *lineWriter) line(content string) {
+	if lw.err != nil {
+		return
+	}
+
+	limit := lineLength
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		lw.write(content[:cut] + "\r\n ")
+		content = content[cut:]
+		// The leading space of continuation lines counts too.
+		limit = lineLength - 1
+	}
+	lw.write(content + "\r\n")
+}
+
+func (lw *lineWriter) write(s string) {
+	if lw.err == nil {
+		_, lw.err = lw.w.WriteString(s)
+	}
+}
+
+func (lw *lineWriter) flush() error {
+	if lw.err != nil {
+		return lw.err
+	}
+	return lw.w.Flush()
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// formatDuration formats d as an RFC 5545 duration, e.g. "PT1H30M".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+
+	res := "P"
+	if days > 0 {
+		res += fmt.Sprintf("%dD", days)
+	}
+	if hours == 0 && minutes == 0 && seconds == 0 {
+		if days == 0 {
+			return "PT0S"
+		}
+		return res
+	}
+	res += "T"
+	if hours > 0 {
+		res += fmt.Sprintf("%dH", hours)
+	}
+	if minutes > 0 {
+		res += fmt.Sprintf("%dM", minutes)
+	}
+	if seconds > 0 {
+		res += fmt.Sprintf("%dS", seconds)
+	}
+	return res
+}
@@ -0,0 +1,218 @@
+package ical
+
+import (
+	"bufio"
+	"cron_expression_parser/parser"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, input string) *parser.Schedule {
+	schedule, err := parser.Parse(input)
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+	return schedule
+}
+
+func TestShouldBuildRRule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"* * * * * cmd", "FREQ=MINUTELY"},
+		{"* 9-17 * * * cmd", "FREQ=MINUTELY;BYHOUR=9,10,11,12,13,14,15,16,17"},
+		{"*/15 * * * * cmd", "FREQ=HOURLY;BYMINUTE=0,15,30,45"},
+		{"30 2 * * 1-5 cmd", "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=2;BYMINUTE=30"},
+		{"0 0 1,15 JAN,JULY * cmd", "FREQ=DAILY;BYMONTH=1,7;BYMONTHDAY=1,15;BYHOUR=0;BYMINUTE=0"},
+		{"0 0 * * SUN cmd", "FREQ=DAILY;BYDAY=SU;BYHOUR=0;BYMINUTE=0"},
+		// Day of week covers every day, so either day field matching is every day.
+		{"0 0 1 * 0-6 cmd", "FREQ=DAILY;BYHOUR=0;BYMINUTE=0"},
+	}
+
+	for _, test := range tests {
+		actual, err := RRule(mustParse(t, test.input))
+		if err != nil {
+			t.Fatalf("Should build RRULE of %q; %s", test.input, err)
+		}
+		if actual != test.expected {
+			t.Errorf("Should build RRULE of %q, expected: %s, actual: %s", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestShouldNotBuildRRuleWhenEitherDayMatches(t *testing.T) {
+	schedule := mustParse(t, "0 3 1,15 * MON cmd")
+	_, err := RRule(schedule)
+	if err == nil {
+		t.Fatalf("Should return error when either day field matches")
+	}
+
+	actual, err := RRule(schedule.WithDayPolicy(parser.DayPolicyAnd))
+	if err != nil || actual != "FREQ=DAILY;BYMONTHDAY=1,15;BYDAY=MO;BYHOUR=3;BYMINUTE=0" {
+		t.Fatalf("Should narrow days down with AND policy, actual: %s; %v", actual, err)
+	}
+}
+
+func TestShouldWriteEventWithRRule(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Skipf("Time zone data is not available; %s", err)
+	}
+
+	calendar := &Calendar{
+		Events: []Event{{Schedule: mustParse(t, "30 2 * * 1-5 /usr/local/bin/backup"), UID: "backup", Duration: 90 * time.Minute}},
+		Start:  time.Date(2026, time.November, 1, 0, 0, 0, 0, warsaw),
+		Stamp:  time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC),
+	}
+	var sb strings.Builder
+	err = calendar.Write(&sb)
+	if err != nil {
+		t.Fatalf("Should write calendar; %s", err)
+	}
+
+	expected := strings.ReplaceAll(`BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//cron_expression_parser//EN
+CALSCALE:GREGORIAN
+BEGIN:VTIMEZONE
+TZID:Europe/Warsaw
+BEGIN:DAYLIGHT
+DTSTART:20250330T020000
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0200
+TZNAME:CEST
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU
+END:DAYLIGHT
+BEGIN:STANDARD
+DTSTART:20251026T030000
+TZOFFSETFROM:+0200
+TZOFFSETTO:+0100
+TZNAME:CET
+RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU
+END:STANDARD
+END:VTIMEZONE
+BEGIN:VEVENT
+UID:backup
+DTSTAMP:20261019T120000Z
+DTSTART;TZID=Europe/Warsaw:20261102T023000
+DURATION:PT1H30M
+RRULE:FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=2;BYMINUTE=30
+SUMMARY:/usr/local/bin/backup
+DESCRIPTION:At 02:30 on every day-of-week from Monday through Friday\n\ncro
+ n: 30 2 * * 1-5 /usr/local/bin/backup
+END:VEVENT
+END:VCALENDAR
+`, "\n", "\r\n")
+	if sb.String() != expected {
+		t.Fatalf("Should write event, expected:\n%s\nactual:\n%s", expected, sb.String())
+	}
+}
+
+func TestShouldWriteTimezoneOfEvents(t *testing.T) {
+	tests := []struct {
+		zone     string
+		expected string
+	}{
+		{"UTC", ""},
+		{"Asia/Tokyo", "BEGIN:STANDARD\r\nDTSTART:19700101T000000\r\nTZOFFSETFROM:+0900\r\nTZOFFSETTO:+0900\r\nTZNAME:JST\r\nEND:STANDARD\r\n"},
+		{"America/New_York", "TZNAME:EDT\r\nRRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU\r\n"},
+		{"Asia/Kolkata", "TZOFFSETTO:+0530\r\n"},
+	}
+
+	for _, test := range tests {
+		location, err := time.LoadLocation(test.zone)
+		if err != nil {
+			t.Skipf("Time zone data is not available; %s", err)
+		}
+		calendar := &Calendar{
+			Events: []Event{{Schedule: mustParse(t, "0 9 * * * cmd")}},
+			Start:  time.Date(2026, time.November, 1, 0, 0, 0, 0, location),
+		}
+		var sb strings.Builder
+		err = calendar.Write(&sb)
+		if err != nil {
+			t.Fatalf("Should write calendar; %s", err)
+		}
+
+		output := sb.String()
+		if test.expected == "" && strings.Contains(output, "VTIMEZONE") {
+			t.Fatalf("Should not write VTIMEZONE for UTC, actual:\n%s", output)
+		}
+		if !strings.Contains(output, test.expected) || (test.expected != "" && strings.Count(output, "BEGIN:VTIMEZONE\r\nTZID:"+test.zone+"\r\n") != 1) {
+			t.Fatalf("Should write VTIMEZONE of %s, actual:\n%s", test.zone, output)
+		}
+	}
+}
+
+func TestShouldListRunsWhenThereIsNoRRule(t *testing.T) {
+	calendar := &Calendar{
+		Events: []Event{{Schedule: mustParse(t, "0 3 1,15 * MON maintenance")}},
+		Start:  time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC),
+		Window: 14 * 24 * time.Hour,
+	}
+	var sb strings.Builder
+	err := calendar.Write(&sb)
+	if err != nil {
+		t.Fatalf("Should write calendar; %s", err)
+	}
+
+	output := sb.String()
+	if strings.Contains(output, "RRULE") {
+		t.Fatalf("Should not write RRULE, actual:\n%s", output)
+	}
+	expected := "DTSTART:20261101T030000Z\r\nRDATE:20261102T030000Z\r\nRDATE:20261109T030000Z\r\nRDATE:20261115T030000Z\r\nSUMMARY:maintenance\r\n"
+	if !strings.Contains(output, expected) {
+		t.Fatalf("Should list runs within the window, actual:\n%s", output)
+	}
+}
+
+func TestShouldNotWriteCalendar(t *testing.T) {
+	tests := []struct {
+		name     string
+		calendar Calendar
+	}{
+		{"never fires", Calendar{Events: []Event{{Schedule: mustParse(t, "0 0 30 2 * cmd")}}, Start: time.Now().UTC()}},
+		{"too many runs", Calendar{Events: []Event{{Schedule: mustParse(t, "* * 1 * MON cmd")}}, Start: time.Now().UTC()}},
+		{"local time zone", Calendar{Events: []Event{{Schedule: mustParse(t, "0 0 * * * cmd")}}, Start: time.Now()}},
+	}
+
+	for _, test := range tests {
+		err := test.calendar.Write(&strings.Builder{})
+		if err == nil {
+			t.Errorf("Should return error when %s", test.name)
+		}
+	}
+}
+
+func TestShouldFoldLongLines(t *testing.T) {
+	var sb strings.Builder
+	lw := &lineWriter{w: bufio.NewWriter(&sb)}
+	lw.property("SUMMARY", strings.Repeat("ą", 50))
+	lw.flush()
+
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\r\n"), "\r\n")
+	if len(lines) != 2 || len(lines[0]) > lineLength || !strings.HasPrefix(lines[1], " ") {
+		t.Fatalf("Should fold line at %d octets, actual: %q", lineLength, lines)
+	}
+	if lines[0]+lines[1][1:] != "SUMMARY:"+strings.Repeat("ą", 50) {
+		t.Fatalf("Should not split characters, actual: %q", lines)
+	}
+}
+
+func TestShouldFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                          "PT0S",
+		15 * time.Minute:           "PT15M",
+		90 * time.Minute:           "PT1H30M",
+		24 * time.Hour:             "P1D",
+		26*time.Hour + time.Second: "P1DT2H1S",
+	}
+
+	for duration, expected := range tests {
+		if actual := formatDuration(duration); actual != expected {
+			t.Errorf("Should format %s, expected: %s, actual: %s", duration, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package ical
+
+import (
+	"cron_expression_parser/parser"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// weekdays are the RRULE names of the days of week, indexed like cron.
+var weekdays = [7]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// RRule returns the RRULE value, e.g. "FREQ=DAILY;BYHOUR=2;BYMINUTE=30",
+// that fires at the same times as the schedule. It returns an error when
+// there is no exact equivalent, which is the case when the schedule fires on
+// days matching either of the day fields: BY parts of a rule always narrow
+// down the occurrences, they cannot add to them.
+func RRule(schedule *parser.Schedule) (string, error) {
+	fields := schedule.Fields()
+	minutes, hours, daysOfMonth, months, daysOfWeek := fields[0], fields[1], fields[2], fields[3], fields[4]
+
+	if schedule.EitherDayMatches() {
+		if !isFull(daysOfMonth) && !isFull(daysOfWeek) {
+			return "", errors.New("The expression fires when either day of month or day of week matches, which a single RRULE cannot express")
+		}
+		// Either field matches every day, so the expression fires daily.
+		daysOfMonth, daysOfWeek = fullField(daysOfMonth), fullField(daysOfWeek)
+	}
+
+	parts := []string{}
+	switch {
+	case isFull(minutes):
+		parts = append(parts, "FREQ=MINUTELY")
+	case isFull(hours):
+		parts = append(parts, "FREQ=HOURLY")
+	default:
+		parts = append(parts, "FREQ=DAILY")
+	}
+
+	if !isFull(months) {
+		parts = append(parts, "BYMONTH="+joinInts(months.Values))
+	}
+	if !isFull(daysOfMonth) {
+		parts = append(parts, "BYMONTHDAY="+joinInts(daysOfMonth.Values))
+	}
+	if !isFull(daysOfWeek) {
+		days := []string{}
+		for _, day := range daysOfWeek.Values {
+			days = append(days, weekdays[day])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if !isFull(hours) {
+		parts = append(parts, "BYHOUR="+joinInts(hours.Values))
+	}
+	if !isFull(minutes) {
+		parts = append(parts, "BYMINUTE="+joinInts(minutes.Values))
+	}
+	return strings.Join(parts, ";"), nil
+}
+
+// isFull reports whether the field allows every value of its range.
+func isFull(field parser.Field) bool {
+	return len(field.Values) == field.GetMaxValue()-field.GetMinValue()+1
+}
+
+func fullField(field parser.Field) parser.Field {
+	field.Values = []int{}
+	for value := field.GetMinValue(); value <= field.GetMaxValue(); value++ {
+		field.Values = append(field.Values, value)
+	}
+	return field
+}
+
+func joinInts(values []int) string {
+	res := make([]string, len(values))
+	for i, value := range values {
+		res[i] = strconv.Itoa(value)
+	}
+	return strings.Join(res, ",")
+}
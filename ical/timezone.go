@@ -0,0 +1,153 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+)
+
+// timezoneYears is how many years of offset changes a VTIMEZONE is built
+// from, starting the year before the first event.
+const timezoneYears = 10
+
+// transition is a change of the UTC offset or the abbreviation of a zone.
+type transition struct {
+	// at is the first instant of the new offset.
+	at   time.Time
+	name string
+	dst  bool
+	from int
+	to   int
+}
+
+// wallClock is the local time the transition happens at, as shown by the
+// clock before it.
+func (t transition) wallClock() time.Time {
+	return t.at.UTC().Add(time.Duration(t.from) * time.Second)
+}
+
+// observance is a STANDARD or DAYLIGHT component: transitions to the same
+// offset and abbreviation.
+type observance struct {
+	name        string
+	dst         bool
+	from        int
+	to          int
+	transitions []transition
+}
+
+// writeTimezone writes the VTIMEZONE of location, so the TZID of the events
+// can be resolved by calendars that do not know the IANA name. Transitions
+// that follow a yearly rule, like the last Sunday of March, are written as
+// an RRULE, others are listed as RDATEs.
+func writeTimezone(lw *lineWriter, location *time.Location, year int) {
+	lw.property("BEGIN", "VTIMEZONE")
+	lw.property("TZID", location.String())
+
+	observances := observances(location, year)
+	if len(observances) == 0 {
+		// A zone without changes keeps the offset it has now.
+		name, offset := time.Date(year, time.January, 1, 0, 0, 0, 0, location).Zone()
+		lw.property("BEGIN", "STANDARD")
+		lw.property("DTSTART", "19700101T000000")
+		lw.property("TZOFFSETFROM", formatOffset(offset))
+		lw.property("TZOFFSETTO", formatOffset(offset))
+		lw.property("TZNAME", escapeText(name))
+		lw.property("END", "STANDARD")
+	}
+
+	for _, o := range observances {
+		component := "STANDARD"
+		if o.dst {
+			component = "DAYLIGHT"
+		}
+		first := o.transitions[0]
+
+		lw.property("BEGIN", component)
+		lw.property("DTSTART", first.wallClock().Format(dateTimeLayout))
+		lw.property("TZOFFSETFROM", formatOffset(o.from))
+		lw.property("TZOFFSETTO", formatOffset(o.to))
+		lw.property("TZNAME", escapeText(o.name))
+		if rule, ok := yearlyRule(o.transitions); ok {
+			lw.property("RRULE", rule)
+		} else {
+			for _, t := range o.transitions[1:] {
+				lw.property("RDATE", t.wallClock().Format(dateTimeLayout))
+			}
+		}
+		lw.property("END", component)
+	}
+	lw.property("END", "VTIMEZONE")
+}
+
+// observances groups the transitions of location within timezoneYears from
+// the start of year by the offset and abbreviation they change to.
+func observances(location *time.Location, year int) []*observance {
+	res := []*observance{}
+	byKey := map[string]*observance{}
+
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, location)
+	limit := time.Date(year+timezoneYears, time.January, 1, 0, 0, 0, 0, location)
+	for _, end := from.ZoneBounds(); !end.IsZero() && end.Before(limit); _, end = end.ZoneBounds() {
+		_, before := end.Add(-time.Nanosecond).Zone()
+		name, after := end.Zone()
+		t := transition{at: end, name: name, dst: end.IsDST(), from: before, to: after}
+
+		key := fmt.Sprintf("%s/%t/%d/%d", t.name, t.dst, t.from, t.to)
+		o, ok := byKey[key]
+		if !ok {
+			o = &observance{name: t.name, dst: t.dst, from: t.from, to: t.to}
+			byKey[key] = o
+			res = append(res, o)
+		}
+		o.transitions = append(o.transitions, t)
+	}
+	return res
+}
+
+// yearlyRule returns an RRULE like "FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU" when
+// the transitions happen every year of the range on the same weekday of a
+// month, counted from its start or its end, and at the same time.
+func yearlyRule(transitions []transition) (string, bool) {
+	if len(transitions) != timezoneYears {
+		return "", false
+	}
+
+	first := transitions[0].wallClock()
+	nth, last := true, true
+	for i, t := range transitions {
+		wall := t.wallClock()
+		if wall.Year() != first.Year()+i || wall.Month() != first.Month() || wall.Weekday() != first.Weekday() ||
+			wall.Hour() != first.Hour() || wall.Minute() != first.Minute() || wall.Second() != first.Second() {
+			return "", false
+		}
+		nth = nth && weekOfMonth(wall) == weekOfMonth(first)
+		last = last && wall.AddDate(0, 0, 7).Month() != wall.Month()
+	}
+
+	weekday := weekdays[first.Weekday()]
+	switch {
+	case nth && weekOfMonth(first) < 5:
+		return fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", first.Month(), weekOfMonth(first), weekday), true
+	case last:
+		return fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=-1%s", first.Month(), weekday), true
+	}
+	return "", false
+}
+
+// weekOfMonth returns which of its weekdays in the month t is, from 1.
+func weekOfMonth(t time.Time) int {
+	return (t.Day()-1)/7 + 1
+}
+
+// formatOffset formats a UTC offset in seconds as "+0100" or "-0430".
+func formatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	res := fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset/60%60)
+	if seconds := offset % 60; seconds != 0 {
+		res += fmt.Sprintf("%02d", seconds)
+	}
+	return res
+}
@@ -0,0 +1,110 @@
+// Package textdiff renders unified diffs of small texts.
+package textdiff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// Unified returns a unified diff of two texts, based on the longest
+// common subsequence of their lines. Crontabs are small, so the quadratic
+// table is fine.
+func Unified(oldName, newName string, oldText, newText []byte) []byte {
+	oldLines := splitLines(oldText)
+	newLines := splitLines(newText)
+
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := []diffOp{}
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			ops = append(ops, diffOp{' ', oldLines[i]})
+			i++
+			j++
+		case i < len(oldLines) && (j == len(newLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', oldLines[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', newLines[j]})
+			j++
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+	for start := 0; start < len(ops); start++ {
+		if ops[start].kind == ' ' {
+			continue
+		}
+
+		// Extend the hunk while the next change is close enough for
+		// the context around both to overlap.
+		end := start + 1
+		for next := end; next < len(ops) && next <= end+2*diffContext; next++ {
+			if ops[next].kind != ' ' {
+				end = next + 1
+			}
+		}
+
+		writeHunk(&out, ops, max(start-diffContext, 0), min(end+diffContext, len(ops)))
+		start = end
+	}
+	return out.Bytes()
+}
+
+func writeHunk(out *bytes.Buffer, ops []diffOp, start, end int) {
+	oldStart, newStart := 1, 1
+	for _, op := range ops[:start] {
+		if op.kind != '+' {
+			oldStart++
+		}
+		if op.kind != '-' {
+			newStart++
+		}
+	}
+
+	oldCount, newCount := 0, 0
+	for _, op := range ops[start:end] {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, op := range ops[start:end] {
+		fmt.Fprintf(out, "%c%s\n", op.kind, op.line)
+	}
+}
+
+func splitLines(text []byte) []string {
+	trimmed := strings.TrimSuffix(string(text), "\n")
+	if len(trimmed) == 0 {
+		return []string{}
+	}
+	return strings.Split(trimmed, "\n")
+}
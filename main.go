@@ -2,22 +2,243 @@ package main
 
 import (
 	"cron_expression_parser/parser"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 )
 
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+)
+
+// cli keeps the streams of a command, so commands can be run in tests.
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+type command struct {
+	summary string
+	run     func(c *cli, args []string) int
+}
+
+// commands are run by their name as the first argument. Anything else is
+// passed to explain, so `cron_parser "*/15 0 1,15 * 1-5 cmd"` keeps working.
+var commands = map[string]command{
+	"explain":  {"print the values of every field", runExplain},
+	"describe": {"print the expression as a sentence", runDescribe},
+	"next":     {"print the next run times", runNext},
+	"calendar": {"show the days and hours the expression fires on", runCalendar},
+	"validate": {"check an expression or a crontab file", runValidate},
+	"convert":  {"rewrite an expression in another notation", runConvert},
+	"rrule":    {"convert an RFC 5545 RRULE to an expression", runRRule},
+	"lint":     {"warn about suspicious expressions", runLint},
+	"fmt":      {"format crontab files", runFmt},
+	"tui":      {"edit an expression interactively", runTUI},
+	"daemon":   {"run the jobs of crontab files", runDaemon},
+}
+
+var commandOrder = []string{"explain", "describe", "next", "calendar", "validate", "convert", "rrule", "lint", "fmt", "tui", "daemon"}
+
 func main() {
-	cliArgs := os.Args[1:]
+	c := &cli{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	os.Exit(c.run(os.Args[1:]))
+}
+
+func (c *cli) run(args []string) int {
+	if len(args) == 0 {
+		c.usage(c.stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "-h", "-help", "--help":
+		c.usage(c.stdout)
+		return exitOK
+	case "help":
+		if len(args) > 1 {
+			if cmd, ok := commands[args[1]]; ok {
+				return cmd.run(c, []string{"--help"})
+			}
+		}
+		c.usage(c.stdout)
+		return exitOK
+	}
+
+	if cmd, ok := commands[args[0]]; ok {
+		return cmd.run(c, args[1:])
+	}
+	if name, rest, ok := legacyCommand(args); ok {
+		return commands[name].run(c, rest)
+	}
+	return runExplain(c, args)
+}
+
+// legacyFlags are the root flags from before there were commands, kept as
+// hidden aliases of their commands so scripts using them keep working.
+var legacyFlags = map[string]string{
+	"normalize": "convert",
+	"describe":  "describe",
+}
+
+// legacyCommand returns the command of a legacy flag found anywhere in args,
+// and args without it. --normalize wins when both are given, as it did.
+func legacyCommand(args []string) (name string, rest []string, ok bool) {
+	rest = []string{}
+	found := map[string]bool{}
+	for i, arg := range args {
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		flagName := strings.TrimSuffix(strings.TrimLeft(arg, "-"), "=true")
+		if _, legacy := legacyFlags[flagName]; legacy && strings.HasPrefix(arg, "-") {
+			found[flagName] = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+
+	for _, flagName := range []string{"normalize", "describe"} {
+		if found[flagName] {
+			return legacyFlags[flagName], rest, true
+		}
+	}
+	return "", nil, false
+}
+
+func (c *cli) usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: cron_parser <command> [flags] [arguments]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, name := range commandOrder {
+		fmt.Fprintf(w, "  %-10s%s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w, "\nExpressions are read from stdin when the argument is \"-\".")
+	fmt.Fprintln(w, "Run \"cron_parser help <command>\" for the flags of a command.")
+	fmt.Fprintln(w, "\nExit codes: 0 valid, 1 invalid, 2 usage error.")
+}
+
+// flagSet returns the flags of a command with a usage message listing its
+// arguments.
+func (c *cli) flagSet(name, arguments, description string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: cron_parser %s [flags] %s\n\n%s\n", name, arguments, description)
+		hasFlags := false
+		flags.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintln(flags.Output(), "\nFlags:")
+			flags.PrintDefaults()
+		}
+	}
+	return flags
+}
 
-	if len(cliArgs) != 1 {
-		fmt.Println("Please provide one argument")
-		os.Exit(1)
+// parseFlags parses flags placed anywhere between the arguments and returns
+// the arguments. ok is false when the command should exit with code.
+func parseFlags(flags *flag.FlagSet, args []string) (positional []string, code int, ok bool) {
+	positional = []string{}
+	for {
+		err := flags.Parse(args)
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, exitOK, false
+		}
+		if err != nil {
+			return nil, exitUsage, false
+		}
+
+		rest := flags.Args()
+		consumed := args[:len(args)-len(rest)]
+		// flag stops at "--" too, after which everything is an argument.
+		if len(rest) == 0 || (len(consumed) > 0 && consumed[len(consumed)-1] == "--") {
+			return append(positional, rest...), exitOK, true
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// usageError prints the message with the usage of the command.
+func usageError(flags *flag.FlagSet, format string, a ...any) int {
+	fmt.Fprintf(flags.Output(), format+"\n", a...)
+	flags.Usage()
+	return exitUsage
+}
+
+func (c *cli) fail(err error) int {
+	fmt.Fprintln(c.stderr, "error:", err)
+	return exitInvalid
+}
+
+// readInput returns the argument, or everything on stdin when it is "-".
+func (c *cli) readInput(arg string) (string, error) {
+	if arg != "-" {
+		return arg, nil
+	}
+	content, err := io.ReadAll(c.stdin)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// openInput opens the file at path, or stdin when it is "-".
+func (c *cli) openInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(c.stdin), nil
+	}
+	return os.Open(path)
+}
+
+func dayPolicyFlag(flags *flag.FlagSet) *string {
+	return flags.String("day-policy", "or", "how restricted day of month and day of week combine: or (Vixie cron) or and")
+}
+
+// parseSchedule parses an expression with or without a command.
+func parseSchedule(input, dayPolicy string) (*parser.Schedule, error) {
+	policy, err := parser.ParseDayPolicy(dayPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule *parser.Schedule
+	if len(strings.Fields(input)) == 5 {
+		schedule, err = parser.ParseTimeFields(input)
+	} else {
+		schedule, err = parser.Parse(input)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return schedule.WithDayPolicy(policy), nil
+}
+
+// expressionArg parses the single expression argument of a command. ok is
+// false when the command should exit with code.
+func (c *cli) expressionArg(flags *flag.FlagSet, args []string, dayPolicy string) (schedule *parser.Schedule, code int, ok bool) {
+	if len(args) != 1 {
+		return nil, usageError(flags, "Please provide one expression"), false
+	}
+
+	if _, err := parser.ParseDayPolicy(dayPolicy); err != nil {
+		return nil, usageError(flags, "%s", err), false
+	}
+
+	input, err := c.readInput(args[0])
+	if err != nil {
+		return nil, c.fail(err), false
 	}
 
-	parser := parser.NewParser()
-	err := parser.Parse(cliArgs[0])
+	schedule, err = parseSchedule(input, dayPolicy)
 	if err != nil {
-		panic(err)
+		return nil, c.fail(err), false
 	}
-	parser.PrintCurrentCronExpression()
+	return schedule, exitOK, true
 }
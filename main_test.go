@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func runCLI(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	c := &cli{stdin: strings.NewReader(stdin), stdout: &stdout, stderr: &stderr}
+	code := c.run(args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestShouldReturnExitCodes(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"validate", "*/15 0 1,15 * 1-5 /usr/bin/find"}, exitOK},
+		{[]string{"validate", "30 2 * * *"}, exitOK},
+		{[]string{"validate", "61 * * * * cmd"}, exitInvalid},
+		{[]string{"validate"}, exitUsage},
+		{[]string{"validate", "--unknown", "* * * * *"}, exitUsage},
+		{[]string{"validate", "--day-policy", "xor", "* * * * *"}, exitUsage},
+		{[]string{"describe", "--locale", "xx", "* * * * *"}, exitUsage},
+		{[]string{"next", "--help"}, exitOK},
+		{[]string{"lint", "0 0 1,1 * *"}, exitInvalid},
+		{[]string{"lint", "0 0 1 * *"}, exitOK},
+		{[]string{}, exitUsage},
+		{[]string{"--help"}, exitOK},
+	}
+
+	for _, test := range tests {
+		code, _, stderr := runCLI("", test.args...)
+		if code != test.code {
+			t.Errorf("Should exit %v with %d, actual: %d; %s", test.args, test.code, code, stderr)
+		}
+	}
+}
+
+func TestShouldReadExpressionFromStdin(t *testing.T) {
+	code, stdout, _ := runCLI("0 9 * * 1-5\n", "describe", "-")
+	if code != exitOK || stdout != "At 09:00 on every day-of-week from Monday through Friday\n" {
+		t.Fatalf("Should describe expression from stdin, actual: %d %q", code, stdout)
+	}
+}
+
+func TestShouldDescribeExpressionWithDayPolicy(t *testing.T) {
+	expected := map[string]string{
+		"or":  "At 00:00 on every day-of-month from 1 through 7 or on Monday\n",
+		"and": "At 00:00 on every day-of-month from 1 through 7 and on Monday\n",
+	}
+	for policy, description := range expected {
+		code, stdout, _ := runCLI("", "describe", "--day-policy", policy, "0 0 1-7 * MON")
+		if code != exitOK || stdout != description {
+			t.Fatalf("Should describe expression with day policy %s, actual: %d %q", policy, code, stdout)
+		}
+	}
+}
+
+func TestShouldKeepLegacyRootFlags(t *testing.T) {
+	code, stdout, _ := runCLI("", "--locale", "de", "--describe", "0 9 * * 1-5")
+	if code != exitOK || stdout != "Um 09:00 an jedem Wochentag von Montag bis Freitag\n" {
+		t.Fatalf("Should describe expression with --describe, actual: %d %q", code, stdout)
+	}
+
+	code, stdout, _ = runCLI("", "0,15,30,45 0 1,15 * 1,2,3,4,5 /usr/bin/find", "--normalize")
+	if code != exitOK || stdout != "*/15 0 1,15 * 1-5 /usr/bin/find\n" {
+		t.Fatalf("Should convert expression with --normalize, actual: %d %q", code, stdout)
+	}
+
+	_, stdout, _ = runCLI("", "--help")
+	if strings.Contains(stdout, "normalize") || strings.Contains(stdout, "--describe") {
+		t.Fatalf("Should not list legacy flags in usage, actual: %q", stdout)
+	}
+}
+
+func TestShouldAcceptFlagsAfterExpression(t *testing.T) {
+	code, stdout, _ := runCLI("", "convert", "0 9 * 1-3 1-5 cmd", "--to", "names")
+	if code != exitOK || stdout != "0 9 * JAN-MAR MON-FRI cmd\n" {
+		t.Fatalf("Should convert expression, actual: %d %q", code, stdout)
+	}
+}
+
+func TestShouldExplainExpressionWithoutCommand(t *testing.T) {
+	code, stdout, _ := runCLI("", "*/15 0 1,15 * 1-5 /usr/bin/find")
+	if code != exitOK || !strings.HasPrefix(stdout, "minute        0 15 30 45") {
+		t.Fatalf("Should explain expression given without a command name, actual: %d %q", code, stdout)
+	}
+}
+
+func TestShouldValidateAndLintCrontab(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crontab")
+	os.WriteFile(path, []byte("MAILTO=\"\"\n0 0 1,1 * * cmd\n"), 0o644)
+
+	code, stdout, _ := runCLI("", "validate", "--crontab", path)
+	if code != exitOK || stdout != "valid: 1 entries\n" {
+		t.Fatalf("Should validate crontab, actual: %d %q", code, stdout)
+	}
+
+	code, stdout, _ = runCLI("", "lint", "--crontab", path)
+	if code != exitInvalid || !strings.HasPrefix(stdout, "line 2: day of month") {
+		t.Fatalf("Should lint crontab entries, actual: %d %q", code, stdout)
+	}
+
+	code, _, stderr := runCLI("61 * * * * cmd\n", "validate", "--crontab", "-")
+	if code != exitInvalid || !strings.Contains(stderr, "-: line 1:") {
+		t.Fatalf("Should report wrong line of crontab from stdin, actual: %d %q", code, stderr)
+	}
+}
+
+func TestShouldFormatCrontabFromStdin(t *testing.T) {
+	code, stdout, _ := runCLI("0   9 * * *   cmd\n", "fmt")
+	if code != exitOK || stdout != "0 9 * * * cmd\n" {
+		t.Fatalf("Should format crontab, actual: %d %q", code, stdout)
+	}
+}
+
+func TestShouldPrintNextRunsInTimeZone(t *testing.T) {
+	code, stdout, stderr := runCLI("", "next", "-n", "3", "--tz", "America/New_York", "--from", "2026-11-01T00:00", "--relative", "30 1 * * *")
+	expected := "2026-11-01T01:30:00-04:00  in 1h30m\n2026-11-02T01:30:00-05:00  in 1d2h30m\n2026-11-03T01:30:00-05:00  in 2d2h30m\n"
+	if code != exitOK || stdout != expected {
+		t.Fatalf("Should print next runs, expected: %q, actual: %d %q; %s", expected, code, stdout, stderr)
+	}
+
+	code, stdout, _ = runCLI("", "next", "-n", "1", "--from", "2026-10-19T10:00:00Z", "--layout", "Mon 02 Jan 15:04", "0 9 * * MON")
+	if code != exitOK || stdout != "Mon 26 Oct 09:00\n" {
+		t.Fatalf("Should print runs in custom layout, actual: %d %q", code, stdout)
+	}
+
+	for _, args := range [][]string{{"--tz", "Mars/Olympus"}, {"--from", "yesterday"}, {"-n", "0"}} {
+		code, _, _ = runCLI("", append(append([]string{"next"}, args...), "* * * * *")...)
+		if code != exitUsage {
+			t.Fatalf("Should return usage error for %v, actual: %d", args, code)
+		}
+	}
+}
+
+func TestShouldFormatRelativeDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		3*time.Hour + 12*time.Minute:  "3h12m",
+		26*time.Hour + 30*time.Second: "1d2h1m",
+		26*time.Hour + 29*time.Second: "1d2h",
+		45 * time.Second:              "1m",
+		0:                             "0m",
+		20 * time.Second:              "0m",
+	}
+
+	for d, expected := range tests {
+		if actual := formatRelative(d); actual != expected {
+			t.Errorf("Should format %s as %q, actual: %q", d, expected, actual)
+		}
+	}
+}
+
+func TestShouldConvertToICal(t *testing.T) {
+	code, stdout, stderr := runCLI("", "convert", "--to", "ical", "--tz", "UTC", "--from", "2026-11-01", "30 2 * * 1-5 backup")
+	if code != exitOK || !strings.Contains(stdout, "DTSTART:20261102T023000Z\r\nDURATION:PT15M\r\nRRULE:FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=2;BYMINUTE=30\r\n") {
+		t.Fatalf("Should export expression with RRULE, actual: %d %q; %s", code, stdout, stderr)
+	}
+
+	code, stdout, stderr = runCLI("", "convert", "--to", "ical", "--from", "2026-11-01", "--window", "168h", "0 3 1,15 * MON")
+	if code != exitOK || !strings.Contains(stdout, "RDATE:20261102T030000Z\r\n") || !strings.HasPrefix(stderr, "warning:") {
+		t.Fatalf("Should fall back to RDATEs with a warning, actual: %d %q; %s", code, stdout, stderr)
+	}
+
+	code, _, _ = runCLI("", "convert", "--crontab", "-", "0 0 * * *")
+	if code != exitUsage {
+		t.Fatalf("Should only export crontabs to ical, actual: %d", code)
+	}
+}
+
+func TestShouldConvertRRuleToExpression(t *testing.T) {
+	code, stdout, _ := runCLI("", "rrule", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0")
+	if code != exitOK || stdout != "0 9 * * 1-5\n" {
+		t.Fatalf("Should convert RRULE, actual: %d %q", code, stdout)
+	}
+
+	code, stdout, stderr := runCLI("DTSTART:20261104T093000Z\nRRULE:FREQ=MONTHLY;BYDAY=1MO\n", "rrule", "--day-policy", "and", "-")
+	if code != exitOK || stdout != "30 9 1-7 * 1\n" || stderr != "" {
+		t.Fatalf("Should convert RRULE with DTSTART from stdin, actual: %d %q; %s", code, stdout, stderr)
+	}
+
+	code, stdout, stderr = runCLI("", "rrule", "--dtstart", "2026-11-04T09:30", "FREQ=MONTHLY;BYDAY=1MO")
+	if code != exitInvalid || stdout != "" || !strings.Contains(stderr, `"30 9 1-7 * 1" is only equivalent with --day-policy and`) {
+		t.Fatalf("Should fail when the expression needs the AND day policy, actual: %d %q; %s", code, stdout, stderr)
+	}
+
+	code, stdout, stderr = runCLI("", "rrule", "FREQ=MONTHLY;BYMONTHDAY=1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21,22,23,24,25,26,27,28,29,30,31;BYDAY=MO;BYHOUR=9;BYMINUTE=0")
+	if code != exitOK || stdout != "0 9 * * 1\n" || stderr != "" {
+		t.Fatalf("Should not warn when only day of week stays restricted, actual: %d %q; %s", code, stdout, stderr)
+	}
+
+	code, _, stderr = runCLI("", "rrule", "INTERVAL=2;FREQ=WEEKLY")
+	if code != exitInvalid || !strings.Contains(stderr, "every 2nd week") {
+		t.Fatalf("Should explain why RRULE cannot be converted, actual: %d %s", code, stderr)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// Embedded so --tz works in images without /usr/share/zoneinfo.
+	_ "time/tzdata"
+)
+
+// fromLayouts are the accepted formats of --from, tried in order.
+var fromLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func runNext(c *cli, args []string) int {
+	flags := c.flagSet("next", "<expression | ->", "Prints the next run times of the expression.")
+	count := flags.Int("n", 5, "number of run times")
+	tz := flags.String("tz", "", "time zone of the schedule, e.g. America/New_York (default local)")
+	from := flags.String("from", "", "start after this time, e.g. 2026-11-01T00:00 (default now)")
+	layout := flags.String("layout", time.RFC3339, "Go time layout of the printed times")
+	relative := flags.Bool("relative", false, "also print how long until each run, e.g. \"in 3h12m\"")
+	dayPolicy := dayPolicyFlag(flags)
+	args, code, ok := parseFlags(flags, args)
+	if !ok {
+		return code
+	}
+
+	if *count < 1 {
+		return usageError(flags, "-n should be at least 1")
+	}
+
+	location := time.Local
+	if *tz != "" {
+		var err error
+		location, err = time.LoadLocation(*tz)
+		if err != nil {
+			return usageError(flags, "Unknown time zone %q", *tz)
+		}
+	}
+
+	start := time.Now().In(location)
+	if *from != "" {
+		var err error
+		start, err = parseFrom(*from, location)
+		if err != nil {
+			return usageError(flags, "%s", err)
+		}
+	}
+
+	schedule, code, ok := c.expressionArg(flags, args, *dayPolicy)
+	if !ok {
+		return code
+	}
+
+	runs := schedule.NextN(start, *count)
+	if len(runs) == 0 {
+		fmt.Fprintln(c.stderr, "warning: the expression does not fire within the next five years")
+	}
+	for _, run := range runs {
+		line := run.Format(*layout)
+		if *relative {
+			line += "  in " + formatRelative(run.Sub(start))
+		}
+		fmt.Fprintln(c.stdout, line)
+	}
+	return exitOK
+}
+
+// parseFrom parses --from in one of fromLayouts. Times without an offset
+// are taken in the given location.
+func parseFrom(value string, location *time.Location) (time.Time, error) {
+	for _, layout := range fromLayouts {
+		t, err := time.ParseInLocation(layout, value, location)
+		if err == nil {
+			return t.In(location), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Cannot parse time %q, should be like 2026-11-01T00:00 or RFC 3339", value)
+}
+
+// formatRelative renders a duration rounded to minutes without zero units,
+// e.g. "2d3h12m" or "45m". Runs start on a minute, so seconds would only
+// tell how far into its minute --from is.
+func formatRelative(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d <= 0 {
+		return "0m"
+	}
+
+	units := []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+	}
+
+	var sb strings.Builder
+	for _, unit := range units {
+		if d >= unit.size {
+			fmt.Fprintf(&sb, "%d%s", d/unit.size, unit.suffix)
+			d %= unit.size
+		}
+	}
+	return sb.String()
+}
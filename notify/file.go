@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// File appends every run as a line of JSON to the file at Path.
+type File struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+func (f *File) Notify(ctx context.Context, n Notification) error {
+	line, err := json.Marshal(newPayload(n))
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+// Package notify delivers the output of job runs, like cron mails it to
+// MAILTO.
+package notify
+
+import (
+	"context"
+	"cron_expression_parser/executor"
+	"cron_expression_parser/parser/crontab"
+	"errors"
+	"time"
+)
+
+// Notification is the outcome of one run of a job.
+type Notification struct {
+	Job    string
+	Result executor.Result
+	// MailTo are the recipients from the crontab MAILTO variable, empty
+	// when it is not set.
+	MailTo []string
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// Func adapts a function to the Notifier interface.
+type Func func(ctx context.Context, n Notification) error
+
+func (f Func) Notify(ctx context.Context, n Notification) error {
+	return f(ctx, n)
+}
+
+// Discard is a notifier that delivers nothing.
+var Discard Notifier = Func(func(ctx context.Context, n Notification) error { return nil })
+
+// Multi delivers every notification to all notifiers, returning all their
+// errors joined.
+func Multi(notifiers ...Notifier) Notifier {
+	return Func(func(ctx context.Context, n Notification) error {
+		errs := []error{}
+		for _, notifier := range notifiers {
+			errs = append(errs, notifier.Notify(ctx, n))
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// ForEnv returns the notifier to use for jobs of a crontab with the given
+// environment, see crontab.File.EnvFor. MAILTO="" disables delivery,
+// other values are passed on as Notification.MailTo.
+func ForEnv(env map[string]string, notifier Notifier) Notifier {
+	recipients, ok := crontab.MailTo(env)
+	if ok && len(recipients) == 0 {
+		return Discard
+	}
+	return Func(func(ctx context.Context, n Notification) error {
+		n.MailTo = recipients
+		return notifier.Notify(ctx, n)
+	})
+}
+
+// payload is what the webhook and file notifiers write for every run.
+type payload struct {
+	Job        string    `json:"job"`
+	Command    string    `json:"command"`
+	ExitCode   int       `json:"exitCode"`
+	Success    bool      `json:"success"`
+	TimedOut   bool      `json:"timedOut,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Output     string    `json:"output"`
+	Truncated  bool      `json:"truncated,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	MailTo     []string  `json:"mailTo,omitempty"`
+}
+
+func newPayload(n Notification) payload {
+	p := payload{
+		Job:        n.Job,
+		Command:    n.Result.Command,
+		ExitCode:   n.Result.ExitCode,
+		Success:    n.Result.Success(),
+		TimedOut:   n.Result.TimedOut,
+		Output:     string(n.Result.Output),
+		Truncated:  n.Result.Truncated,
+		StartedAt:  n.Result.StartedAt,
+		FinishedAt: n.Result.FinishedAt,
+		MailTo:     n.MailTo,
+	}
+	if n.Result.Err != nil {
+		p.Error = n.Result.Err.Error()
+	}
+	return p
+}
@@ -0,0 +1,235 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"cron_expression_parser/executor"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mail struct {
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTP accepts mails on a local port and sends them to the returned
+// channel.
+func fakeSMTP(t *testing.T) (string, <-chan mail) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Should listen; %s", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	mails := make(chan mail, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, mails)
+		}
+	}()
+	return listener.Addr().String(), mails
+}
+
+func serveSMTP(conn net.Conn, mails chan<- mail) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 fake ESMTP")
+	current := mail{}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 fake")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			current.from = strings.Trim(strings.TrimSpace(line)[10:], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			current.to = append(current.to, strings.Trim(strings.TrimSpace(line)[8:], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil || line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			current.data = data.String()
+			mails <- current
+			current = mail{}
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func exampleNotification() Notification {
+	return Notification{
+		Job: "report",
+		Result: executor.Result{
+			Command:  "report.sh",
+			Output:   []byte("line one\n.dot line\n"),
+			ExitCode: 1,
+			Err:      errors.New("exit status 1"),
+		},
+	}
+}
+
+func TestShouldMailOutputToMailTo(t *testing.T) {
+	addr, mails := fakeSMTP(t)
+	notifier := ForEnv(map[string]string{"MAILTO": "ops@example.com,dev@example.com"}, &SMTP{Addr: addr, From: "cron@example.com"})
+
+	err := notifier.Notify(context.Background(), exampleNotification())
+	if err != nil {
+		t.Fatalf("Should send mail; %s", err)
+	}
+
+	m := <-mails
+	if m.from != "cron@example.com" || len(m.to) != 2 || m.to[1] != "dev@example.com" {
+		t.Fatalf("Should send mail to MAILTO, actual: %+v", m)
+	}
+
+	for _, expected := range []string{"Subject: Cron <report> report.sh\r\n", "X-Cron-Exit-Code: 1\r\n", "line one\r\n..dot line\r\n", "exit status 1\r\n"} {
+		if !strings.Contains(m.data, expected) {
+			t.Fatalf("Should contain %q, actual: %q", expected, m.data)
+		}
+	}
+}
+
+func TestShouldKeepJobNameInSubject(t *testing.T) {
+	addr, mails := fakeSMTP(t)
+	notification := exampleNotification()
+	notification.Job = "report\r\nBcc: attacker@example.com"
+
+	err := (&SMTP{Addr: addr, To: []string{"ops@example.com"}}).Notify(context.Background(), notification)
+	if err != nil {
+		t.Fatalf("Should send mail; %s", err)
+	}
+
+	m := <-mails
+	if strings.Contains(m.data, "\r\nBcc:") {
+		t.Fatalf("Should not let the job name add headers, actual: %q", m.data)
+	}
+}
+
+func TestShouldGiveUpMailingWhenContextIsDone(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Should listen; %s", err)
+	}
+	defer listener.Close()
+	// The server accepts connections but never greets.
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	err = (&SMTP{Addr: listener.Addr().String(), To: []string{"ops@example.com"}}).Notify(ctx, exampleNotification())
+	if err == nil || time.Since(started) > time.Second {
+		t.Fatalf("Should give up when the context is done, actual: %v after %s", err, time.Since(started))
+	}
+}
+
+func TestShouldNotMailQuietSuccessfulRuns(t *testing.T) {
+	addr, mails := fakeSMTP(t)
+	notifier := &SMTP{Addr: addr, To: []string{"ops@example.com"}}
+
+	err := notifier.Notify(context.Background(), Notification{Job: "quiet", Result: executor.Result{Command: "true"}})
+	if err != nil || len(mails) != 0 {
+		t.Fatalf("Should not send mail without output; %v", err)
+	}
+}
+
+func TestShouldDisableDeliveryWithEmptyMailTo(t *testing.T) {
+	called := false
+	notifier := ForEnv(map[string]string{"MAILTO": ""}, Func(func(ctx context.Context, n Notification) error {
+		called = true
+		return nil
+	}))
+
+	notifier.Notify(context.Background(), exampleNotification())
+	if called {
+		t.Fatalf("Should not deliver with empty MAILTO")
+	}
+}
+
+func TestShouldPostRunToWebhook(t *testing.T) {
+	var received payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	notifier := &Webhook{URL: server.URL, Header: http.Header{"Authorization": {"Bearer token"}}}
+	err := notifier.Notify(context.Background(), exampleNotification())
+	if err != nil {
+		t.Fatalf("Should post to webhook; %s", err)
+	}
+
+	if received.Job != "report" || received.ExitCode != 1 || received.Success || received.Output != "line one\n.dot line\n" {
+		t.Fatalf("Should post run, actual: %+v", received)
+	}
+
+	notifier.Header = nil
+	if notifier.Notify(context.Background(), exampleNotification()) == nil {
+		t.Fatalf("Should return error for failed request")
+	}
+}
+
+func TestShouldAppendRunsToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runs.jsonl")
+	notifier := &File{Path: path}
+
+	notifier.Notify(context.Background(), exampleNotification())
+	notifier.Notify(context.Background(), exampleNotification())
+
+	content, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Should append a line per run, actual: %q", content)
+	}
+
+	var first payload
+	err := json.Unmarshal([]byte(lines[0]), &first)
+	if err != nil || first.Command != "report.sh" || first.Error != "exit status 1" {
+		t.Fatalf("Should write run as JSON, actual: %+v; %v", first, err)
+	}
+}
@@ -0,0 +1,147 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+)
+
+var ErrNoRecipients = errors.New("No mail recipients")
+
+// SMTP mails the output of runs. Like cron, it only sends a mail when the
+// run printed something, unless Always is set; failed runs are always
+// reported.
+type SMTP struct {
+	// Addr is the host:port of the mail server.
+	Addr string
+	Auth smtp.Auth
+	From string
+	// To is used when the crontab does not set MAILTO.
+	To     []string
+	Always bool
+}
+
+func (s *SMTP) Notify(ctx context.Context, n Notification) error {
+	if len(n.Result.Output) == 0 && n.Result.Success() && !s.Always {
+		return nil
+	}
+
+	to := n.MailTo
+	if len(to) == 0 {
+		to = s.To
+	}
+	if len(to) == 0 {
+		return ErrNoRecipients
+	}
+
+	return s.send(ctx, to, s.message(n, to))
+}
+
+// send does what smtp.SendMail does, but gives up when ctx is done, also in
+// the middle of the conversation with a stuck server.
+func (s *SMTP) send(ctx context.Context, to []string, msg []byte) error {
+	host, _, err := net.SplitHostPort(s.Addr)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		err = c.StartTLS(&tls.Config{ServerName: host})
+		if err != nil {
+			return err
+		}
+	}
+	if s.Auth != nil {
+		err = c.Auth(s.Auth)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.Mail(s.from())
+	if err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		err = c.Rcpt(recipient)
+		if err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(msg)
+	if err != nil {
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (s *SMTP) from() string {
+	if s.From != "" {
+		return s.From
+	}
+	host, _ := os.Hostname()
+	return "cron@" + host
+}
+
+func (s *SMTP) message(n Notification, to []string) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", headerValue(s.from()))
+	fmt.Fprintf(&msg, "To: %s\r\n", headerValue(strings.Join(to, ", ")))
+	fmt.Fprintf(&msg, "Subject: Cron <%s> %s\r\n", headerValue(n.Job), headerValue(n.Result.Command))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&msg, "X-Cron-Exit-Code: %d\r\n\r\n", n.Result.ExitCode)
+
+	body := string(n.Result.Output)
+	if n.Result.Truncated {
+		body += "\n[output truncated]\n"
+	}
+	if n.Result.Err != nil {
+		body += fmt.Sprintf("\n%s\n", n.Result.Err)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(body, "\n"), "\n") {
+		// Dot-stuffing is done by net/smtp, only line endings are fixed.
+		msg.WriteString(strings.TrimSuffix(line, "\r") + "\r\n")
+	}
+	return msg.Bytes()
+}
+
+// headerValue keeps a value on one header line, so a job name or command
+// cannot add headers of its own.
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,24 @@
+package parser
+
+import "time"
+
+// FiresOn reports whether the expression fires at least once on the day of
+// t, taking the month and both day fields into account.
+func (p *Parser) FiresOn(t time.Time) bool {
+	return p.months.Has(int(t.Month())) && p.dayMatches(t)
+}
+
+// RunsPerDay returns how many times the expression fires on a day it fires
+// on, i.e. the number of minutes times the number of hours.
+func (p *Parser) RunsPerDay() int {
+	return p.minutes.Count() * p.hours.Count()
+}
+
+// RunsInHour returns how many times the expression fires in the given hour
+// of a day it fires on.
+func (p *Parser) RunsInHour(hour int) int {
+	if !p.hours.Has(hour) {
+		return 0
+	}
+	return p.minutes.Count()
+}
@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShouldCountRunsOfDaysItFiresOn(t *testing.T) {
+	parser := NewParser()
+	err := parser.Parse("*/15 9-17 10 * FRI cmd")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	tests := map[int]bool{
+		10: true,  // day of month
+		13: true,  // Friday
+		14: false, // neither
+	}
+	for day, expected := range tests {
+		if parser.FiresOn(time.Date(2026, time.November, day, 0, 0, 0, 0, time.UTC)) != expected {
+			t.Fatalf("Should fire on November %d: %v", day, expected)
+		}
+	}
+
+	if parser.RunsPerDay() != 36 || parser.RunsInHour(9) != 4 || parser.RunsInHour(8) != 0 {
+		t.Fatalf("Should count runs, actual: %d per day, %d at 9", parser.RunsPerDay(), parser.RunsInHour(9))
+	}
+}
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"cron_expression_parser/parser/consts"
+	"cron_expression_parser/parser/helpers"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Canonical returns the shortest cron expression (without the command)
+// equivalent to the parsed one, e.g. "0,15,30,45 0 * * 1,2,3,4,5" becomes
+// "*/15 0 * * 1-5".
+func (p *Parser) Canonical() string {
+	return strings.Join(p.CanonicalFields(false), " ")
+}
+
+// CanonicalFields returns the canonical form of each of the five fields.
+// With names set, months and days of week are written as JAN-MAR or MON-FRI.
+// When a day matches if either day field does, the day fields are not
+// written starting with "*", as that would make them unrestricted and turn
+// "0 0 1-31 * MON" (every day) into "0 0 * * 1" (Mondays).
+func (p *Parser) CanonicalFields(names bool) []string {
+	monthOf, dayOfWeekOf := strconv.Itoa, strconv.Itoa
+	if names {
+		monthOf, dayOfWeekOf = nameOf(consts.MonthToNum), nameOf(consts.DayOfWeekToNum)
+	}
+	daysOfMonth, daysOfWeek := p.daysOfMonth.Values(), p.daysOfWeek.Values()
+	restricted := p.eitherDayMatches()
+	// When either day field allows every day, so does the expression and
+	// both can be written as "*".
+	if restricted && (isFullField(daysOfMonth, &consts.DayOfMonth{}) || isFullField(daysOfWeek, &consts.DayOfWeek{})) {
+		daysOfMonth, daysOfWeek = fullField(&consts.DayOfMonth{}), fullField(&consts.DayOfWeek{})
+		restricted = false
+	}
+
+	return []string{
+		canonicalField(p.minutes.Values(), &consts.Minutes{}, strconv.Itoa, false),
+		canonicalField(p.hours.Values(), &consts.Hours{}, strconv.Itoa, false),
+		canonicalField(daysOfMonth, &consts.DayOfMonth{}, strconv.Itoa, restricted),
+		canonicalField(p.months.Values(), &consts.Month{}, monthOf, false),
+		canonicalField(daysOfWeek, &consts.DayOfWeek{}, dayOfWeekOf, restricted),
+	}
+}
+
+// String returns the canonical expression followed by the command.
+func (p *Parser) String() string {
+	if p.command == "" {
+		return p.Canonical()
+	}
+	return p.Canonical() + " " + p.command
+}
+
+func isFullField(values []int, partType consts.Value) bool {
+	return len(values) == partType.GetMaxValue()-partType.GetMinValue()+1
+}
+
+func fullField(partType consts.Value) []int {
+	res := []int{}
+	for value := partType.GetMinValue(); value <= partType.GetMaxValue(); value++ {
+		res = append(res, value)
+	}
+	return res
+}
+
+// canonicalField writes the values as the shortest list of segments. A
+// restricted field is never written starting with "*".
+func canonicalField(values []int, partType consts.Value, valueOf func(int) string, restricted bool) string {
+	segments := helpers.CollapseValues(values, partType.GetMinValue(), partType.GetMaxValue())
+	if !restricted && helpers.IsFullRange(segments, partType.GetMinValue(), partType.GetMaxValue()) {
+		return consts.ASTERIKS
+	}
+
+	parts := []string{}
+	for _, segment := range segments {
+		parts = append(parts, canonicalSegment(segment, partType, valueOf, restricted))
+	}
+	return strings.Join(parts, consts.LISTING_OPRATOR)
+}
+
+func canonicalSegment(segment helpers.Segment, partType consts.Value, valueOf func(int) string, restricted bool) string {
+	if segment.IsSingle() {
+		return valueOf(segment.Start)
+	}
+
+	if segment.Step == 1 {
+		return valueOf(segment.Start) + consts.RANGE_OPERATOR + valueOf(segment.Stop)
+	}
+
+	if !restricted && segment.IsFullStep(partType.GetMinValue(), partType.GetMaxValue()) {
+		return fmt.Sprintf("%s%s%d", consts.ASTERIKS, consts.STEP_OPERATOR, segment.Step)
+	}
+
+	return fmt.Sprintf("%s%s%s%s%d", valueOf(segment.Start), consts.RANGE_OPERATOR, valueOf(segment.Stop), consts.STEP_OPERATOR, segment.Step)
+}
+
+// nameOf reverses one of the name to number maps from consts.
+func nameOf(nameToNum map[string]string) func(int) string {
+	numToName := map[string]string{}
+	for name, num := range nameToNum {
+		numToName[num] = name
+	}
+	return func(value int) string {
+		return numToName[strconv.Itoa(value)]
+	}
+}
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShouldRenderCanonicalExpression(t *testing.T) {
+	testScenarios := []struct {
+		input    string
+		expected string
+	}{
+		{"* * * * * cmd", "* * * * *"},
+		{"0,15,30,45 0 1,15 * 1,2,3,4,5 cmd", "*/15 0 1,15 * 1-5"},
+		{"0-59 0-23 1-31 1-12 0-6 cmd", "* * * * *"},
+		{"1/20 1-10/2 */10 JAN-MAR MON,FRI cmd", "1-41/20 1-9/2 */10 1-3 1,5"},
+		{"0,30 0,12 1-3,5 * SAT,SUN cmd", "0,30 0,12 1-3,5 * 0,6"},
+	}
+
+	for _, scenario := range testScenarios {
+		parser := NewParser()
+		err := parser.Parse(scenario.input)
+		if err != nil {
+			t.Fatalf("Should not return error with proper input; %s", err)
+		}
+
+		if parser.Canonical() != scenario.expected {
+			t.Fatalf("Should render %q canonically, expected: %q, actual: %q", scenario.input, scenario.expected, parser.Canonical())
+		}
+	}
+}
+
+func TestShouldParseCanonicalExpressionToTheSameValues(t *testing.T) {
+	inputs := []string{
+		"0,15,30,45 0 1,15 * 1,2,3,4,5 cmd with args",
+		"1/20 1-10/2 */10 JAN-MAR MON,FRI cmd",
+		"1,2,3,5,7,9 */7 2,4,6,8 */4 * cmd",
+	}
+
+	for _, input := range inputs {
+		parser := NewParser()
+		err := parser.Parse(input)
+		if err != nil {
+			t.Fatalf("Should not return error with proper input; %s", err)
+		}
+
+		normalized := NewParser()
+		err = normalized.Parse(parser.String())
+		if err != nil {
+			t.Fatalf("Should parse canonical expression %q; %s", parser.String(), err)
+		}
+
+		if normalized.String() != parser.String() || normalized.command != parser.command {
+			t.Fatalf("Should keep %q equivalent after normalizing, expected: %q, actual: %q", input, parser.String(), normalized.String())
+		}
+	}
+}
+
+func TestShouldKeepDayFieldsRestricted(t *testing.T) {
+	testScenarios := []struct {
+		input    string
+		policy   DayPolicy
+		expected string
+	}{
+		// Either field allowing every day fires daily.
+		{"0 0 1-31 * MON cmd", DayPolicyOr, "0 0 * * *"},
+		{"0 0 1 * 0-6 cmd", DayPolicyOr, "0 0 * * *"},
+		{"0 0 1-31/2 * SUN-SAT/2 cmd", DayPolicyOr, "0 0 1-31/2 * 0-6/2"},
+		{"0 0 1-31/2 * MON cmd", DayPolicyOr, "0 0 1-31/2 * 1"},
+		{"0 0 1-31 * * cmd", DayPolicyOr, "0 0 * * *"},
+		{"0 0 */2 * MON cmd", DayPolicyOr, "0 0 */2 * 1"},
+		{"0 0 1-31 * MON cmd", DayPolicyAnd, "0 0 * * 1"},
+	}
+
+	for _, scenario := range testScenarios {
+		schedule, err := Parse(scenario.input)
+		if err != nil {
+			t.Fatalf("Should not return error with proper input; %s", err)
+		}
+		schedule = schedule.WithDayPolicy(scenario.policy)
+
+		if schedule.Canonical() != scenario.expected {
+			t.Fatalf("Should render %q canonically, expected: %q, actual: %q", scenario.input, scenario.expected, schedule.Canonical())
+		}
+
+		normalized, err := ParseTimeFields(schedule.Canonical())
+		if err != nil {
+			t.Fatalf("Should parse canonical expression; %s", err)
+		}
+		normalized = normalized.WithDayPolicy(scenario.policy)
+		from := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+		expected, actual := schedule.NextN(from, 40), normalized.NextN(from, 40)
+		for i := range expected {
+			if !expected[i].Equal(actual[i]) {
+				t.Fatalf("Should fire at the same times as %q, expected: %v, actual: %v", scenario.input, expected, actual)
+			}
+		}
+	}
+}
+
+func TestShouldWriteThreeLetterMonthNames(t *testing.T) {
+	for _, input := range []string{"0 0 1 6,7 * cmd", "0 0 1 JUN,JUL * cmd", "0 0 1 JUNE,JULY * cmd", "0 0 1 JUN-JULY * cmd"} {
+		schedule, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Should accept month names in %q; %s", input, err)
+		}
+
+		fields := schedule.CanonicalFields(true)
+		if fields[3] != "JUN,JUL" {
+			t.Fatalf("Should write %q with three letter month names, actual: %q", input, fields[3])
+		}
+	}
+}
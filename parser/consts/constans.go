@@ -36,18 +36,25 @@ var DayOfWeekToNum = map[string]string{
 }
 
 var MonthToNum = map[string]string{
-	"JAN":  "1",
-	"FEB":  "2",
-	"MAR":  "3",
-	"APR":  "4",
-	"MAY":  "5",
-	"JUNE": "6",
-	"JULY": "7",
-	"AUG":  "8",
-	"SEP":  "9",
-	"OCT":  "10",
-	"NOV":  "11",
-	"DEC":  "12",
+	"JAN": "1",
+	"FEB": "2",
+	"MAR": "3",
+	"APR": "4",
+	"MAY": "5",
+	"JUN": "6",
+	"JUL": "7",
+	"AUG": "8",
+	"SEP": "9",
+	"OCT": "10",
+	"NOV": "11",
+	"DEC": "12",
+}
+
+// MonthAliases are longer month names accepted on input, they are replaced
+// before MonthToNum so "JUNE" does not become "6E".
+var MonthAliases = map[string]string{
+	"JUNE": "JUN",
+	"JULY": "JUL",
 }
 
 type Value interface {
@@ -0,0 +1,247 @@
+package crontab
+
+import (
+	"bufio"
+	"cron_expression_parser/parser"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+type LineKind int
+
+const (
+	Blank LineKind = iota
+	Comment
+	Env
+	Entry
+	// Reboot is an "@reboot" entry, run once when cron starts. It has no
+	// Expression.
+	Reboot
+)
+
+// RebootMacro runs the command once when cron starts instead of on a
+// schedule.
+const RebootMacro = "@reboot"
+
+// Macros that can be used instead of the five time fields.
+var Macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var envLine = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+
+// idComment names the entry below it, e.g. "# id: nightly-backup".
+var idComment = regexp.MustCompile(`^#\s*id:\s*(\S+)\s*$`)
+
+// Line is a single line of a crontab file. Only the fields matching its
+// Kind are set.
+type Line struct {
+	Kind   LineKind
+	Number int
+	Raw    string
+
+	Name  string
+	Value string
+
+	Macro      string
+	Fields     []string
+	Command    string
+	Expression *parser.Schedule
+	// ID is set from an "# id:" comment on the line above the entry.
+	ID string
+}
+
+type File struct {
+	Lines []Line
+}
+
+// LineError points at the crontab line that could not be parsed.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+func Parse(r io.Reader) (*File, error) {
+	file := &File{}
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		line, err := parseLine(scanner.Text())
+		if err != nil {
+			return nil, &LineError{Line: number, Err: err}
+		}
+		line.Number = number
+		if (line.Kind == Entry || line.Kind == Reboot) && len(file.Lines) > 0 {
+			line.ID = commentID(file.Lines[len(file.Lines)-1])
+		}
+		file.Lines = append(file.Lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func parseLine(raw string) (Line, error) {
+	trimmed := strings.TrimSpace(raw)
+	line := Line{Raw: raw}
+
+	if len(trimmed) == 0 {
+		line.Kind = Blank
+		return line, nil
+	}
+
+	if strings.HasPrefix(trimmed, "#") {
+		line.Kind = Comment
+		return line, nil
+	}
+
+	if match := envLine.FindStringSubmatch(trimmed); match != nil {
+		line.Kind = Env
+		line.Name = match[1]
+		line.Value = unquote(strings.TrimSpace(match[2]))
+		return line, nil
+	}
+
+	if strings.Fields(trimmed)[0] == RebootMacro {
+		line.Kind = Reboot
+		line.Macro = RebootMacro
+		line.Command = strings.TrimLeft(strings.TrimPrefix(trimmed, RebootMacro), " \t")
+		if line.Command == "" {
+			return line, errors.New("Entry is in wrong format, should be: '@reboot Command_to_execute'")
+		}
+		return line, nil
+	}
+
+	line.Kind = Entry
+	fieldCount := 5
+	if strings.HasPrefix(trimmed, "@") {
+		fieldCount = 1
+	}
+
+	split := strings.Fields(trimmed)
+	if len(split) <= fieldCount {
+		return line, errors.New("Entry is in wrong format, should be: '* * * * * Command_to_execute'")
+	}
+
+	line.Fields = split[:fieldCount]
+	rest := trimmed
+	for _, field := range line.Fields {
+		rest = strings.TrimLeft(strings.TrimPrefix(rest, field), " \t")
+	}
+	line.Command = rest
+
+	schedule := strings.Join(line.Fields, " ")
+	if fieldCount == 1 {
+		line.Macro = line.Fields[0]
+		expanded, ok := Macros[line.Macro]
+		if !ok {
+			return line, errors.New(fmt.Sprintf("Unsupported macro %s", line.Macro))
+		}
+		schedule = expanded
+	}
+
+	expression, err := parser.Parse(schedule + " " + line.Command)
+	if err != nil {
+		return line, err
+	}
+	line.Expression = expression
+	return line, nil
+}
+
+func commentID(line Line) string {
+	if line.Kind != Comment {
+		return ""
+	}
+	if match := idComment.FindStringSubmatch(strings.TrimSpace(line.Raw)); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// Entries returns only the job lines of the file.
+func (f *File) Entries() []Line {
+	res := []Line{}
+	for _, line := range f.Lines {
+		if line.Kind == Entry {
+			res = append(res, line)
+		}
+	}
+	return res
+}
+
+// RebootEntries returns the "@reboot" lines of the file.
+func (f *File) RebootEntries() []Line {
+	res := []Line{}
+	for _, line := range f.Lines {
+		if line.Kind == Reboot {
+			res = append(res, line)
+		}
+	}
+	return res
+}
+
+// Env returns the environment set by the file. As in cron, a variable
+// only applies to the entries below it, so this is the final state.
+func (f *File) Env() map[string]string {
+	res := map[string]string{}
+	for _, line := range f.Lines {
+		if line.Kind == Env {
+			res[line.Name] = line.Value
+		}
+	}
+	return res
+}
+
+// EnvFor returns the environment set by the lines above the given entry,
+// which is what cron runs that entry with.
+func (f *File) EnvFor(entry Line) map[string]string {
+	res := map[string]string{}
+	for _, line := range f.Lines {
+		if line.Number >= entry.Number {
+			break
+		}
+		if line.Kind == Env {
+			res[line.Name] = line.Value
+		}
+	}
+	return res
+}
+
+// MailTo returns the recipients listed in MAILTO. ok is false when MAILTO
+// is not set at all, while MAILTO="" gives no recipients with ok set,
+// meaning that output delivery is disabled.
+func MailTo(env map[string]string) (recipients []string, ok bool) {
+	value, ok := env["MAILTO"]
+	if !ok {
+		return nil, false
+	}
+	recipients = strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	return recipients, true
+}
@@ -0,0 +1,181 @@
+package crontab
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const exampleCrontab = `# backups
+SHELL=/bin/sh
+MAILTO="ops@example.com"
+
+0,15,30,45  0 1,15 * 1,2,3,4,5 /usr/bin/find
+5 4 * * MON	echo hi  there
+@daily   /bin/true
+`
+
+func TestShouldParseCrontabLines(t *testing.T) {
+	file, err := Parse(strings.NewReader(exampleCrontab))
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	expectedKinds := []LineKind{Comment, Env, Env, Blank, Entry, Entry, Entry}
+	if len(file.Lines) != len(expectedKinds) {
+		t.Fatalf("Should parse all lines, expected: %d, actual: %d", len(expectedKinds), len(file.Lines))
+	}
+	for i, line := range file.Lines {
+		if line.Kind != expectedKinds[i] {
+			t.Fatalf("Should parse line %d as %v, actual: %v", i+1, expectedKinds[i], line.Kind)
+		}
+	}
+
+	env := file.Env()
+	if env["SHELL"] != "/bin/sh" || env["MAILTO"] != "ops@example.com" {
+		t.Fatalf("Should parse environment lines, actual: %v", env)
+	}
+
+	entries := file.Entries()
+	if entries[1].Command != "echo hi  there" {
+		t.Fatalf("Should keep command as written, actual: %q", entries[1].Command)
+	}
+
+	if entries[2].Macro != "@daily" || entries[2].Expression.Canonical() != "0 0 * * *" {
+		t.Fatalf("Should expand macros, actual: %q", entries[2].Expression.Canonical())
+	}
+}
+
+func TestShouldReturnLineNumberOfWrongEntry(t *testing.T) {
+	_, err := Parse(strings.NewReader("# header\n61 * * * * cmd\n"))
+
+	var lineErr *LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 2 {
+		t.Fatalf("Should return error for line 2, actual: %v", err)
+	}
+}
+
+func TestShouldReadMailToForEntry(t *testing.T) {
+	file, err := Parse(strings.NewReader("0 * * * * first\nMAILTO=\"a@example.com, b@example.com\"\n0 * * * * second\nMAILTO=\"\"\n0 * * * * third\n"))
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+	entries := file.Entries()
+
+	_, ok := MailTo(file.EnvFor(entries[0]))
+	if ok {
+		t.Fatalf("Should not set MAILTO above its line")
+	}
+
+	recipients, ok := MailTo(file.EnvFor(entries[1]))
+	if !ok || len(recipients) != 2 || recipients[1] != "b@example.com" {
+		t.Fatalf("Should split recipients, actual: %v", recipients)
+	}
+
+	recipients, ok = MailTo(file.EnvFor(entries[2]))
+	if !ok || len(recipients) != 0 {
+		t.Fatalf("Should disable delivery with empty MAILTO, actual: %v", recipients)
+	}
+}
+
+func TestShouldReadEntryID(t *testing.T) {
+	file, err := Parse(strings.NewReader("# id: nightly-backup\n0 1 * * * backup.sh\n# backups\n0 2 * * * other.sh\n"))
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	entries := file.Entries()
+	if entries[0].ID != "nightly-backup" || entries[1].ID != "" {
+		t.Fatalf("Should take ID only from id comments, actual: %q, %q", entries[0].ID, entries[1].ID)
+	}
+}
+
+func TestShouldFormatCrontab(t *testing.T) {
+	expected := `# backups
+SHELL=/bin/sh
+MAILTO="ops@example.com"
+
+*/15 0 1,15 * MON-FRI /usr/bin/find
+5    4 *    * MON     echo hi  there
+@daily /bin/true
+`
+
+	res, err := Format([]byte(exampleCrontab), FormatOptions{Names: true})
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	if string(res) != expected {
+		t.Fatalf("Should format crontab, expected:\n%s\nactual:\n%s", expected, res)
+	}
+
+	again, err := Format(res, FormatOptions{Names: true})
+	if err != nil || string(again) != string(res) {
+		t.Fatalf("Should not change already formatted crontab, actual:\n%s", again)
+	}
+}
+
+func TestShouldKeepRebootEntries(t *testing.T) {
+	src := "# id: warmup\n@reboot   /usr/local/bin/warmup --all\n0 0 1-31 * MON daily.sh\n"
+	file, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Should accept @reboot; %s", err)
+	}
+
+	reboots := file.RebootEntries()
+	if len(reboots) != 1 || reboots[0].Command != "/usr/local/bin/warmup --all" || reboots[0].ID != "warmup" || len(file.Entries()) != 1 {
+		t.Fatalf("Should parse @reboot apart from scheduled entries, actual: %v", reboots)
+	}
+
+	res, err := Format([]byte(src), FormatOptions{})
+	if err != nil {
+		t.Fatalf("Should format crontab with @reboot; %s", err)
+	}
+	// Either day field matching is enough, so the entry fires daily.
+	expected := "# id: warmup\n@reboot /usr/local/bin/warmup --all\n0 0 * * * daily.sh\n"
+	if string(res) != expected {
+		t.Fatalf("Should keep @reboot and the meaning of entries, expected:\n%s\nactual:\n%s", expected, res)
+	}
+}
+
+func TestShouldFormatDayFieldsThatFireDaily(t *testing.T) {
+	res, err := Format([]byte("0,15,30,45 0 1-31 6 1,2,3,4,5 cmd\n"), FormatOptions{})
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	if string(res) != "*/15 0 * 6 * cmd\n" {
+		t.Fatalf("Should format the expression as convert does, actual: %q", res)
+	}
+}
+
+func TestShouldListAndRewriteUnformattedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crontab")
+	err := os.WriteFile(path, []byte("0,15,30,45  * * * * cmd\n"), 0600)
+	if err != nil {
+		t.Fatalf("Should write crontab; %s", err)
+	}
+
+	in, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Should open crontab; %s", err)
+	}
+	defer in.Close()
+
+	var out bytes.Buffer
+	err = FormatFile(path, in, &out, FormatFileOptions{List: true, Write: true})
+	if err != nil {
+		t.Fatalf("Should format file; %s", err)
+	}
+	if out.String() != path+"\n" {
+		t.Fatalf("Should list the unformatted file, actual: %q", out.String())
+	}
+
+	res, _ := os.ReadFile(path)
+	if string(res) != "*/15 * * * * cmd\n" {
+		t.Fatalf("Should rewrite the file, actual: %q", res)
+	}
+}
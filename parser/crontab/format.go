@@ -0,0 +1,141 @@
+package crontab
+
+import (
+	"bytes"
+	"cron_expression_parser/internal/textdiff"
+	"cron_expression_parser/parser"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+type FormatOptions struct {
+	// Names writes months and days of week as JAN-MAR or MON-FRI.
+	Names bool
+}
+
+// FormatFileOptions are the gofmt flags of cronfmt and the fmt command.
+type FormatFileOptions struct {
+	FormatOptions
+	// List prints the path of a file whose formatting differs.
+	List bool
+	// Diff prints a unified diff of a file whose formatting differs.
+	Diff bool
+	// Write rewrites the file at path when its formatting differs.
+	Write bool
+}
+
+// Format rewrites src canonically: every expression is normalized and the
+// time fields of all entries are aligned in columns. Comments, blank lines
+// and environment lines are kept as they are.
+func Format(src []byte, options FormatOptions) ([]byte, error) {
+	file, err := Parse(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([][]string, len(file.Lines))
+	widths := make([]int, 5)
+	for i, line := range file.Lines {
+		if line.Kind != Entry || line.Macro != "" {
+			continue
+		}
+		columns[i] = canonicalFields(line, options)
+		for j, column := range columns[i] {
+			widths[j] = max(widths[j], len(column))
+		}
+	}
+
+	var out bytes.Buffer
+	for i, line := range file.Lines {
+		switch {
+		case line.Kind == Blank:
+		case line.Kind == Reboot:
+			out.WriteString(line.Macro + " " + line.Command)
+		case line.Kind != Entry:
+			out.WriteString(line.Raw)
+		case line.Macro != "":
+			out.WriteString(line.Macro + " " + line.Command)
+		default:
+			for j, column := range columns[i] {
+				out.WriteString(column + strings.Repeat(" ", widths[j]-len(column)+1))
+			}
+			out.WriteString(line.Command)
+		}
+		out.WriteString("\n")
+	}
+	return out.Bytes(), nil
+}
+
+// canonicalFields returns the canonical fields of the entry. A field whose
+// canonical form would make the entry fire at other times is kept as
+// written, field by field, as a formatter must never change when a job
+// runs.
+func canonicalFields(line Line, options FormatOptions) []string {
+	canonical := line.Expression.CanonicalFields(options.Names)
+	if equivalent(line, canonical) {
+		return canonical
+	}
+
+	fields := slices.Clone(line.Fields)
+	for i := range fields {
+		written := fields[i]
+		fields[i] = canonical[i]
+		if !equivalent(line, fields) {
+			fields[i] = written
+		}
+	}
+	return fields
+}
+
+func equivalent(line Line, fields []string) bool {
+	schedule, err := parser.ParseTimeFields(strings.Join(fields, " "))
+	return err == nil && schedule.Equivalent(line.Expression)
+}
+
+// FormatFile formats the crontab read from in. Without List, Diff or Write
+// the result goes to out, otherwise out only gets the path or diff of a file
+// whose formatting differs, like with gofmt.
+func FormatFile(path string, in io.Reader, out io.Writer, options FormatFileOptions) error {
+	src, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	res, err := Format(src, options.FormatOptions)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if !options.List && !options.Diff && !options.Write {
+		_, err = out.Write(res)
+		return err
+	}
+
+	if bytes.Equal(src, res) {
+		return nil
+	}
+
+	if options.List {
+		fmt.Fprintln(out, path)
+	}
+
+	if options.Write {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(path, res, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.Diff {
+		_, err = out.Write(textdiff.Unified(path+".orig", path, src, res))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package parser
+
+import (
+	"cron_expression_parser/parser/consts"
+	"cron_expression_parser/parser/helpers"
+	"cron_expression_parser/parser/locale"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type describedField struct {
+	field    locale.Field
+	values   []int
+	partType consts.Value
+	valueOf  func(int) string
+}
+
+type segmentMessage struct {
+	locale.Field
+	Value string
+	From  string
+	To    string
+	Step  int
+	Nth   string
+}
+
+type describer struct {
+	catalog *locale.Catalog
+	err     error
+}
+
+// Describe renders the parsed expression in plain English,
+// e.g. "At every 15th minute past hour 0 on day-of-month 1 and 15".
+func (p *Parser) Describe() string {
+	description, err := p.DescribeIn(locale.Default)
+	if err != nil {
+		return err.Error()
+	}
+	return description
+}
+
+// DescribeIn renders the parsed expression using the message catalog of the
+// given locale, see locale.Names for the supported ones.
+func (p *Parser) DescribeIn(localeName string) (string, error) {
+	catalog, err := locale.Lookup(localeName)
+	if err != nil {
+		return "", err
+	}
+	d := &describer{catalog: catalog}
+
+	minutes := describedField{catalog.Minute, p.minutes.Values(), &consts.Minutes{}, strconv.Itoa}
+	hours := describedField{catalog.Hour, p.hours.Values(), &consts.Hours{}, strconv.Itoa}
+	daysOfMonth := describedField{catalog.DayOfMonth, p.daysOfMonth.Values(), &consts.DayOfMonth{}, strconv.Itoa}
+	months := describedField{catalog.Month, p.months.Values(), &consts.Month{}, catalog.MonthName}
+	daysOfWeek := describedField{catalog.DayOfWeek, p.daysOfWeek.Values(), &consts.DayOfWeek{}, catalog.DayOfWeekName}
+
+	description := d.describeTime(minutes, hours)
+
+	description += d.describeDays(daysOfMonth, daysOfWeek, p.eitherDayMatches())
+
+	if !months.isEvery() {
+		description += d.render(locale.InMonth, d.describe(months, false))
+	}
+
+	if d.err != nil {
+		return "", d.err
+	}
+	return capitalize(description), nil
+}
+
+// describeDays renders the day fields, joined with "or" when a day matches
+// when either of them does. Then a field covering every day makes the
+// expression fire daily, so no days are described at all.
+func (d *describer) describeDays(daysOfMonth, daysOfWeek describedField, either bool) string {
+	if either && (daysOfMonth.isEvery() || daysOfWeek.isEvery()) {
+		return ""
+	}
+
+	description := ""
+	if !daysOfMonth.isEvery() {
+		description += d.render(locale.OnDayOfMonth, d.describe(daysOfMonth, true))
+	}
+
+	if !daysOfWeek.isEvery() {
+		key := locale.OnDayOfWeek
+		switch {
+		case either:
+			key = locale.OrOnDayOfWeek
+		case !daysOfMonth.isEvery():
+			key = locale.AndOnDayOfWeek
+		}
+		description += d.render(key, d.describe(daysOfWeek, false))
+	}
+	return description
+}
+
+func (d *describer) describeTime(minutes, hours describedField) string {
+	if len(minutes.values) == 1 && len(hours.values) == 1 {
+		return d.render(locale.AtTime, fmt.Sprintf("%02d:%02d", hours.values[0], minutes.values[0]))
+	}
+
+	description := d.render(locale.At, d.describe(minutes, true))
+	if !hours.isEvery() {
+		description += d.render(locale.Past, d.describe(hours, true))
+	}
+	return description
+}
+
+func (f describedField) segments() []helpers.Segment {
+	return helpers.CollapseValues(f.values, f.partType.GetMinValue(), f.partType.GetMaxValue())
+}
+
+func (f describedField) isEvery() bool {
+	return helpers.IsFullRange(f.segments(), f.partType.GetMinValue(), f.partType.GetMaxValue())
+}
+
+// describe renders all segments of the field, prefixing single values with
+// the field name when withName is set ("minute 5" vs "Monday").
+func (d *describer) describe(f describedField, withName bool) string {
+	segments := f.segments()
+	if helpers.IsFullRange(segments, f.partType.GetMinValue(), f.partType.GetMaxValue()) {
+		return d.render(locale.Every, segmentMessage{Field: f.field})
+	}
+
+	parts := []string{}
+	for _, segment := range segments {
+		parts = append(parts, d.describeSegment(f, segment))
+	}
+
+	description := d.joinWithAnd(parts)
+	if withName && segments[0].IsSingle() {
+		description = d.render(locale.Named, segmentMessage{Field: f.field, Value: description})
+	}
+	return description
+}
+
+func (d *describer) describeSegment(f describedField, segment helpers.Segment) string {
+	if segment.IsSingle() {
+		return f.valueOf(segment.Start)
+	}
+
+	stop := segment.Stop
+	if stop+segment.Step > f.partType.GetMaxValue() {
+		stop = f.partType.GetMaxValue()
+	}
+	field := f.field
+	field.Plural = d.catalog.PluralOf(segment.Step, f.field)
+	message := segmentMessage{
+		Field: field,
+		From:  f.valueOf(segment.Start),
+		To:    f.valueOf(stop),
+		Step:  segment.Step,
+		Nth:   d.catalog.Ordinal(segment.Step),
+	}
+
+	if segment.Step == 1 {
+		return d.render(locale.EveryRange, message)
+	}
+
+	if segment.IsFullStep(f.partType.GetMinValue(), f.partType.GetMaxValue()) {
+		return d.render(locale.EveryStep, message)
+	}
+
+	return d.render(locale.EveryStepRange, message)
+}
+
+// render executes the catalog message, remembering the first error so the
+// sentence can be built without checking after every fragment.
+func (d *describer) render(key string, data any) string {
+	var sb strings.Builder
+	err := d.catalog.Template(key).Execute(&sb, data)
+	if err != nil && d.err == nil {
+		d.err = err
+	}
+	return sb.String()
+}
+
+func (d *describer) joinWithAnd(parts []string) string {
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], d.catalog.Separator) + d.catalog.And + parts[len(parts)-1]
+}
+
+func capitalize(s string) string {
+	first, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(first)) + s[size:]
+}
@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"cron_expression_parser/parser/locale"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestShouldDescribeExpressionInEnglish(t *testing.T) {
+	testScenarios := []struct {
+		input    string
+		expected string
+	}{
+		{"* * * * * cmd", "At every minute"},
+		{"5 0 * * * cmd", "At 00:05"},
+		{"5 * * * * cmd", "At minute 5"},
+		{"*/15 0 1,15 * 1-5 cmd", "At every 15th minute past hour 0 on day-of-month 1 and 15 or on every day-of-week from Monday through Friday"},
+		{"1-3,5 * * * * cmd", "At every minute from 1 through 3 and 5"},
+		{"1/20 9-17 * * * cmd", "At every 20th minute from 1 through 59 past every hour from 9 through 17"},
+		{"0 0,12 * * * cmd", "At minute 0 past hour 0 and 12"},
+		{"0 0 * JAN-MAR MON,FRI cmd", "At 00:00 on Monday and Friday in every month from January through March"},
+		{"0 0 1 */3 * cmd", "At 00:00 on day-of-month 1 in every 3rd month"},
+		{"0 0 1-31 * 1 cmd", "At 00:00"},
+		{"0 0 1,15 * 0-6 cmd", "At 00:00"},
+		{"0 0 */2 * 1 cmd", "At 00:00 on every 2nd day-of-month and on Monday"},
+	}
+
+	for _, scenario := range testScenarios {
+		parser := NewParser()
+		err := parser.Parse(scenario.input)
+		if err != nil {
+			t.Fatalf("Should not return error with proper input; %s", err)
+		}
+
+		if parser.Describe() != scenario.expected {
+			t.Fatalf("Should describe %q properly, expected: %q, actual: %q", scenario.input, scenario.expected, parser.Describe())
+		}
+	}
+}
+
+var update = flag.Bool("update", false, "update golden files")
+
+var describedExpressions = []string{
+	"* * * * * cmd",
+	"5 0 * * * cmd",
+	"5 * * * * cmd",
+	"*/15 0 1,15 * 1-5 cmd",
+	"1-3,5 * * * * cmd",
+	"1/20 9-17 * * * cmd",
+	"0 0 1 */3 * cmd",
+	"30 4 * JAN-MAR MON,FRI cmd",
+	"0 12 1-7 * SAT,SUN cmd",
+	"0 0 1-31 * 1 cmd",
+	"0 0 1,15 * 0-6 cmd",
+}
+
+func TestShouldDescribeExpressionsInEveryLocale(t *testing.T) {
+	for _, localeName := range locale.Names() {
+		var sb strings.Builder
+		for _, input := range describedExpressions {
+			parser := NewParser()
+			err := parser.Parse(input)
+			if err != nil {
+				t.Fatalf("Should not return error with proper input; %s", err)
+			}
+
+			description, err := parser.DescribeIn(localeName)
+			if err != nil {
+				t.Fatalf("Should describe %q in %s; %s", input, localeName, err)
+			}
+			fmt.Fprintf(&sb, "%s\t%s\n", input, description)
+		}
+
+		golden := filepath.Join("testdata", "describe", localeName+".golden")
+		if *update {
+			err := os.WriteFile(golden, []byte(sb.String()), 0644)
+			if err != nil {
+				t.Fatalf("Should update golden file; %s", err)
+			}
+		}
+
+		expected, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("Should read golden file; %s", err)
+		}
+
+		if string(expected) != sb.String() {
+			t.Fatalf("Should describe expressions in %s as in %s, expected:\n%s\nactual:\n%s", localeName, golden, expected, sb.String())
+		}
+	}
+}
+
+func TestShouldPickPolishPluralForm(t *testing.T) {
+	testScenarios := []struct {
+		input    string
+		expected string
+	}{
+		{"*/2 * * * * cmd", "Co 2 minuty"},
+		{"*/5 * * * * cmd", "Co 5 minut"},
+		{"*/12 * * * * cmd", "Co 12 minut"},
+		{"*/22 * * * * cmd", "Co 22 minuty"},
+		{"0 0 1 */3 * cmd", "O 00:00, dzień miesiąca 1, co 3 miesiące"},
+		{"0 0 1 */5 * cmd", "O 00:00, dzień miesiąca 1, co 5 miesięcy"},
+	}
+
+	for _, scenario := range testScenarios {
+		schedule, err := Parse(scenario.input)
+		if err != nil {
+			t.Fatalf("Should not return error with proper input; %s", err)
+		}
+
+		description, err := schedule.DescribeIn("pl")
+		if err != nil || description != scenario.expected {
+			t.Fatalf("Should describe %q in Polish, expected: %q, actual: %q; %v", scenario.input, scenario.expected, description, err)
+		}
+	}
+}
+
+func TestShouldReturnErrorForUnsupportedLocale(t *testing.T) {
+	parser := NewParser()
+	err := parser.Parse("* * * * * cmd")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	_, err = parser.DescribeIn("xx")
+	if err == nil {
+		t.Fatalf("Should return error for unsupported locale")
+	}
+}
+
+func TestShouldDescribeConcurrently(t *testing.T) {
+	schedule, err := Parse("*/15 0 1,15 * 1-5 cmd")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, localeName := range locale.Names() {
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func(localeName string) {
+				defer wg.Done()
+				_, err := schedule.DescribeIn(localeName)
+				if err != nil {
+					t.Errorf("Should describe in %s; %s", localeName, err)
+				}
+			}(localeName)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,52 @@
+package helpers
+
+import "math/bits"
+
+// Bitset holds values from 0 to 63, one bit per value. It is large enough
+// for every cron field, as in Vixie cron.
+type Bitset uint64
+
+func NewBitset(values ...int) Bitset {
+	var b Bitset
+	for _, value := range values {
+		b |= 1 << uint(value)
+	}
+	return b
+}
+
+func (b Bitset) Has(value int) bool {
+	return value >= 0 && value < 64 && b&(1<<uint(value)) != 0
+}
+
+// Values returns the values in the set in ascending order.
+func (b Bitset) Values() []int {
+	res := make([]int, 0, b.Count())
+	for rest := uint64(b); rest != 0; rest &= rest - 1 {
+		res = append(res, bits.TrailingZeros64(rest))
+	}
+	return res
+}
+
+func (b Bitset) Count() int {
+	return bits.OnesCount64(uint64(b))
+}
+
+func (b Bitset) IsEmpty() bool {
+	return b == 0
+}
+
+func (b Bitset) Union(other Bitset) Bitset {
+	return b | other
+}
+
+func (b Bitset) Intersect(other Bitset) Bitset {
+	return b & other
+}
+
+func (b Bitset) Difference(other Bitset) Bitset {
+	return b &^ other
+}
+
+func (b Bitset) IsSubsetOf(other Bitset) bool {
+	return b&^other == 0
+}
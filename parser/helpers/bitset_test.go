@@ -0,0 +1,38 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShouldKeepBitsetValuesSortedAndUnique(t *testing.T) {
+	b := NewBitset(5, 1, 1, 2, 3, 59)
+	if !reflect.DeepEqual(b.Values(), []int{1, 2, 3, 5, 59}) || b.Count() != 5 {
+		t.Fatalf("Should return sorted unique values, actual: %v", b.Values())
+	}
+
+	if !b.Has(59) || b.Has(0) || b.Has(-1) || b.Has(64) {
+		t.Fatalf("Should report membership properly")
+	}
+}
+
+func TestShouldPerformSetOperations(t *testing.T) {
+	a := NewBitset(1, 2, 3)
+	b := NewBitset(3, 4)
+
+	if !reflect.DeepEqual(a.Union(b).Values(), []int{1, 2, 3, 4}) {
+		t.Fatalf("Should return union, actual: %v", a.Union(b).Values())
+	}
+
+	if !reflect.DeepEqual(a.Intersect(b).Values(), []int{3}) {
+		t.Fatalf("Should return intersection, actual: %v", a.Intersect(b).Values())
+	}
+
+	if !reflect.DeepEqual(a.Difference(b).Values(), []int{1, 2}) {
+		t.Fatalf("Should return difference, actual: %v", a.Difference(b).Values())
+	}
+
+	if !NewBitset(1, 2).IsSubsetOf(a) || b.IsSubsetOf(a) {
+		t.Fatalf("Should check subsets properly")
+	}
+}
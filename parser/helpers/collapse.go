@@ -0,0 +1,57 @@
+package helpers
+
+import "slices"
+
+// Segment is a run of values start, start+step, ..., stop.
+type Segment struct {
+	Start int
+	Stop  int
+	Step  int
+}
+
+func (s Segment) IsSingle() bool {
+	return s.Start == s.Stop
+}
+
+// IsFullStep reports whether the segment is what "*/step" expands to.
+func (s Segment) IsFullStep(minValue, maxValue int) bool {
+	return s.Start == minValue && s.Stop+s.Step > maxValue
+}
+
+func IsFullRange(segments []Segment, minValue, maxValue int) bool {
+	return len(segments) == 1 && segments[0].Start == minValue && segments[0].Stop == maxValue && segments[0].Step == 1
+}
+
+// CollapseValues turns expanded values back into the shortest list of
+// segments, e.g. [0 15 30 45] into one segment 0-45 with step 15. Two values
+// stay a list even when they make up "*/step": SAT,SUN would otherwise become
+// 0-6/6 and read as "every 6th day-of-week", and 0,12 is as short as */12.
+func CollapseValues(values []int, minValue, maxValue int) []Segment {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	res := []Segment{}
+	for i := 0; i < len(sorted); {
+		if i+1 == len(sorted) {
+			res = append(res, Segment{Start: sorted[i], Stop: sorted[i], Step: 1})
+			break
+		}
+
+		step := sorted[i+1] - sorted[i]
+		j := i + 1
+		for j+1 < len(sorted) && sorted[j+1]-sorted[j] == step {
+			j++
+		}
+
+		if j-i+1 >= 3 {
+			res = append(res, Segment{Start: sorted[i], Stop: sorted[j], Step: step})
+			i = j + 1
+			continue
+		}
+
+		res = append(res, Segment{Start: sorted[i], Stop: sorted[i], Step: 1})
+		i++
+	}
+	return res
+}
@@ -0,0 +1,26 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShouldCollapseValuesIntoSegments(t *testing.T) {
+	testScenarios := []struct {
+		values   []int
+		min, max int
+		expected []Segment
+	}{
+		{[]int{0, 15, 30, 45}, 0, 59, []Segment{{0, 45, 15}}},
+		{[]int{1, 2, 3, 5}, 0, 59, []Segment{{1, 3, 1}, {5, 5, 1}}},
+		{[]int{0, 12}, 0, 23, []Segment{{0, 0, 1}, {12, 12, 1}}},
+		{[]int{0, 6}, 0, 6, []Segment{{0, 0, 1}, {6, 6, 1}}},
+	}
+
+	for _, scenario := range testScenarios {
+		actual := CollapseValues(scenario.values, scenario.min, scenario.max)
+		if !reflect.DeepEqual(actual, scenario.expected) {
+			t.Errorf("Should collapse %v, expected: %v, actual: %v", scenario.values, scenario.expected, actual)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"strings"
 )
 
+const maxRangeLength = 64
+
 func GenerateValuesForRange(start string, stop string) ([]int, error) {
 	startParsed, err := strconv.Atoi(start)
 	if err != nil {
@@ -32,6 +34,12 @@ func GenerateValuesForRange(start string, stop string) ([]int, error) {
 		return []int{}, errors.New("Wrong range values")
 	}
 
+	// No field has more values, longer ranges are invalid anyway and would
+	// only take memory.
+	if stopParsed-startParsed > maxRangeLength {
+		return []int{}, errors.New(fmt.Sprintf("Range %d-%d is too long", startParsed, stopParsed))
+	}
+
 	res := []int{}
 	for i := startParsed; i <= stopParsed; i++ {
 		res = append(res, i)
@@ -55,6 +63,10 @@ func GenerateValuesForRangeWithStep(start string, step string, maxValue, minValu
 		return []int{}, err
 	}
 
+	if stepParsed < 1 {
+		return []int{}, errors.New(fmt.Sprintf("Step %d should be at least 1", stepParsed))
+	}
+
 	res := []int{}
 	for i := startParsed; i <= maxValue; i += stepParsed {
 		res = append(res, i)
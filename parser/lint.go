@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"cron_expression_parser/parser/consts"
+	"cron_expression_parser/parser/helpers"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Warning points at a part of a valid expression that is most likely not
+// what its author meant, or that can be written more simply.
+type Warning struct {
+	Field   string
+	Token   string
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %q %s", w.Field, w.Token, w.Message)
+}
+
+// Warnings returns the lint warnings of the last parsed expression.
+func (p *Parser) Warnings() []Warning {
+	return append(slices.Clone(p.warnings), p.lintDayCombination()...)
+}
+
+// lintRedundantItems reports list items whose values are already covered by
+// the other items, e.g. "1" in "5,1,1-3". Items are checked from the last
+// one, so of two identical items the later one is reported.
+func lintRedundantItems(inputPart string, partType consts.Value) []Warning {
+	items := strings.Split(inputPart, consts.LISTING_OPRATOR)
+	if len(items) < 2 {
+		return []Warning{}
+	}
+
+	values := make([]helpers.Bitset, len(items))
+	for i, item := range items {
+		res, err := parsePart(item, partType)
+		if err != nil {
+			return []Warning{}
+		}
+		values[i] = helpers.NewBitset(res...)
+	}
+
+	redundant := make([]bool, len(items))
+	for i := len(items) - 1; i >= 0; i-- {
+		var others helpers.Bitset
+		for j := range items {
+			if j != i && !redundant[j] {
+				others = others.Union(values[j])
+			}
+		}
+		redundant[i] = values[i].IsSubsetOf(others)
+	}
+
+	res := []Warning{}
+	for i, item := range items {
+		if redundant[i] {
+			res = append(res, Warning{Field: partType.GetName(), Token: item, Message: "is already covered by the other items of the list"})
+		}
+	}
+	return res
+}
+
+// lintDayCombination warns about expressions like "0 0 1-7 * MON", which
+// under Vixie cron fire on each of the first seven days and on every Monday,
+// while their author most likely meant the first Monday of the month.
+func (p *Parser) lintDayCombination() []Warning {
+	if !p.daysOfMonthRestricted || !p.daysOfWeekRestricted || p.dayPolicy == DayPolicyAnd {
+		return []Warning{}
+	}
+
+	days := p.daysOfMonth.Values()
+	start := days[0]
+	if len(days) != 7 || days[6] != start+6 || (start-1)%7 != 0 {
+		return []Warning{}
+	}
+
+	message := "fires on either day field, use the AND day policy to fire only when both match"
+	if p.daysOfWeek.Count() == 1 {
+		dayOfWeek := nameOf(consts.DayOfWeekToNum)(p.daysOfWeek.Values()[0])
+		message += fmt.Sprintf(", or %s#%d where the dialect supports it", dayOfWeek, (start-1)/7+1)
+	}
+	return []Warning{{Field: "day of month", Token: p.tokens[2] + " " + p.tokens[4], Message: message}}
+}
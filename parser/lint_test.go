@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShouldSortAndDeduplicateListedValues(t *testing.T) {
+	parser := NewParser()
+	err := parser.Parse("5,1,1-3 5,1,1-3 5,1,1-3 5,1,1-3 5,1,1-3 cmd")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	expected := []int{1, 2, 3, 5}
+	for _, field := range parser.Schedule().Fields() {
+		if !reflect.DeepEqual(field.Values, expected) {
+			t.Fatalf("Should sort and deduplicate %s, expected: %v, actual: %v", field.GetName(), expected, field.Values)
+		}
+	}
+}
+
+func TestShouldWarnAboutRedundantListItems(t *testing.T) {
+	testScenarios := []struct {
+		input    string
+		expected []Warning
+	}{
+		{"1-3,5 * * * * cmd", []Warning{}},
+		{"5,1,1-3 * * * * cmd", []Warning{{"minutes", "1", "is already covered by the other items of the list"}}},
+		{"* 1-3,1-3 * * * cmd", []Warning{{"hours", "1-3", "is already covered by the other items of the list"}}},
+		{"* * * * MON-FRI,WED,6 cmd", []Warning{{"day of week", "WED", "is already covered by the other items of the list"}}},
+		{"0-30/10,0,30 * * * * cmd", []Warning{
+			{"minutes", "0", "is already covered by the other items of the list"},
+			{"minutes", "30", "is already covered by the other items of the list"},
+		}},
+	}
+
+	for _, scenario := range testScenarios {
+		parser := NewParser()
+		err := parser.Parse(scenario.input)
+		if err != nil {
+			t.Fatalf("Should not return error with proper input; %s", err)
+		}
+
+		if !reflect.DeepEqual(parser.Warnings(), scenario.expected) {
+			t.Fatalf("Should warn about %q, expected: %v, actual: %v", scenario.input, scenario.expected, parser.Warnings())
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package locale
+
+import "fmt"
+
+func init() {
+	register("de", &Catalog{
+		Minute:     Field{Name: "Minute", Plural: "Minuten", Every: "jede Minute"},
+		Hour:       Field{Name: "Stunde", Plural: "Stunden", Every: "jede Stunde"},
+		DayOfMonth: Field{Name: "Tag des Monats", Plural: "Tage des Monats", Every: "jedem Tag des Monats"},
+		Month:      Field{Name: "Monat", Plural: "Monate", Every: "jedem Monat"},
+		DayOfWeek:  Field{Name: "Wochentag", Plural: "Wochentage", Every: "jedem Wochentag"},
+		DayOfWeeks: [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		Months: [13]string{"", "Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember"},
+		And:       " und ",
+		Separator: ", ",
+		Ordinal:   func(n int) string { return fmt.Sprintf("%d.", n) },
+		Messages: map[string]string{
+			AtTime:         "Um {{.}}",
+			At:             "{{.}}",
+			Past:           " in {{.}}",
+			OnDayOfMonth:   " an {{.}}",
+			OnDayOfWeek:    " an {{.}}",
+			AndOnDayOfWeek: " und an {{.}}",
+			OrOnDayOfWeek:  " oder an {{.}}",
+			InMonth:        " in {{.}}",
+			Every:          "{{.Every}}",
+			EveryRange:     "{{.Every}} von {{.From}} bis {{.To}}",
+			EveryStep:      "alle {{.Step}} {{.Plural}}",
+			EveryStepRange: "alle {{.Step}} {{.Plural}} von {{.From}} bis {{.To}}",
+			Named:          "{{.Name}} {{.Value}}",
+		},
+	})
+}
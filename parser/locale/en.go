@@ -0,0 +1,49 @@
+package locale
+
+import "fmt"
+
+func init() {
+	register("en", &Catalog{
+		Minute:     Field{Name: "minute", Plural: "minutes", Every: "every minute"},
+		Hour:       Field{Name: "hour", Plural: "hours", Every: "every hour"},
+		DayOfMonth: Field{Name: "day-of-month", Plural: "days-of-month", Every: "every day-of-month"},
+		Month:      Field{Name: "month", Plural: "months", Every: "every month"},
+		DayOfWeek:  Field{Name: "day-of-week", Plural: "days-of-week", Every: "every day-of-week"},
+		DayOfWeeks: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		Months: [13]string{"", "January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December"},
+		And:       " and ",
+		Separator: ", ",
+		Ordinal:   englishOrdinal,
+		Messages: map[string]string{
+			AtTime:         "At {{.}}",
+			At:             "At {{.}}",
+			Past:           " past {{.}}",
+			OnDayOfMonth:   " on {{.}}",
+			OnDayOfWeek:    " on {{.}}",
+			AndOnDayOfWeek: " and on {{.}}",
+			OrOnDayOfWeek:  " or on {{.}}",
+			InMonth:        " in {{.}}",
+			Every:          "{{.Every}}",
+			EveryRange:     "{{.Every}} from {{.From}} through {{.To}}",
+			EveryStep:      "every {{.Nth}} {{.Name}}",
+			EveryStepRange: "every {{.Nth}} {{.Name}} from {{.From}} through {{.To}}",
+			Named:          "{{.Name}} {{.Value}}",
+		},
+	})
+}
+
+func englishOrdinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
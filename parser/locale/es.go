@@ -0,0 +1,34 @@
+package locale
+
+import "fmt"
+
+func init() {
+	register("es", &Catalog{
+		Minute:     Field{Name: "minuto", Plural: "minutos", Every: "cada minuto"},
+		Hour:       Field{Name: "hora", Plural: "horas", Every: "cada hora"},
+		DayOfMonth: Field{Name: "día del mes", Plural: "días del mes", Every: "cada día del mes"},
+		Month:      Field{Name: "mes", Plural: "meses", Every: "cada mes"},
+		DayOfWeek:  Field{Name: "día de la semana", Plural: "días de la semana", Every: "cada día de la semana"},
+		DayOfWeeks: [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		Months: [13]string{"", "enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		And:       " y ",
+		Separator: ", ",
+		Ordinal:   func(n int) string { return fmt.Sprintf("%dº", n) },
+		Messages: map[string]string{
+			AtTime:         "A las {{.}}",
+			At:             "{{.}}",
+			Past:           " durante {{.}}",
+			OnDayOfMonth:   " en {{.}}",
+			OnDayOfWeek:    " en {{.}}",
+			AndOnDayOfWeek: " y en {{.}}",
+			OrOnDayOfWeek:  " o en {{.}}",
+			InMonth:        " en {{.}}",
+			Every:          "{{.Every}}",
+			EveryRange:     "{{.Every}} de {{.From}} a {{.To}}",
+			EveryStep:      "cada {{.Step}} {{.Plural}}",
+			EveryStepRange: "cada {{.Step}} {{.Plural}} de {{.From}} a {{.To}}",
+			Named:          "{{.Name}} {{.Value}}",
+		},
+	})
+}
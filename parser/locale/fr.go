@@ -0,0 +1,34 @@
+package locale
+
+import "fmt"
+
+func init() {
+	register("fr", &Catalog{
+		Minute:     Field{Name: "minute", Plural: "minutes", Every: "chaque minute"},
+		Hour:       Field{Name: "heure", Plural: "heures", Every: "chaque heure"},
+		DayOfMonth: Field{Name: "jour du mois", Plural: "jours du mois", Every: "chaque jour du mois"},
+		Month:      Field{Name: "mois", Plural: "mois", Every: "chaque mois"},
+		DayOfWeek:  Field{Name: "jour de la semaine", Plural: "jours de la semaine", Every: "chaque jour de la semaine"},
+		DayOfWeeks: [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		Months: [13]string{"", "janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		And:       " et ",
+		Separator: ", ",
+		Ordinal:   func(n int) string { return fmt.Sprintf("%de", n) },
+		Messages: map[string]string{
+			AtTime:         "À {{.}}",
+			At:             "{{.}}",
+			Past:           " pendant {{.}}",
+			OnDayOfMonth:   ", {{.}}",
+			OnDayOfWeek:    ", {{.}}",
+			AndOnDayOfWeek: " et {{.}}",
+			OrOnDayOfWeek:  " ou {{.}}",
+			InMonth:        ", {{.}}",
+			Every:          "{{.Every}}",
+			EveryRange:     "{{.Every}} de {{.From}} à {{.To}}",
+			EveryStep:      "chaque {{.Nth}} {{.Name}}",
+			EveryStepRange: "chaque {{.Nth}} {{.Name}} de {{.From}} à {{.To}}",
+			Named:          "{{.Name}} {{.Value}}",
+		},
+	})
+}
@@ -0,0 +1,114 @@
+package locale
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"text/template"
+)
+
+const Default = "en"
+
+// Message keys used by the describer. Every catalog has to provide all of them.
+const (
+	AtTime         = "atTime"
+	At             = "at"
+	Past           = "past"
+	OnDayOfMonth   = "onDayOfMonth"
+	OnDayOfWeek    = "onDayOfWeek"
+	AndOnDayOfWeek = "andOnDayOfWeek"
+	OrOnDayOfWeek  = "orOnDayOfWeek"
+	InMonth        = "inMonth"
+	Every          = "every"
+	EveryRange     = "everyRange"
+	EveryStep      = "everyStep"
+	EveryStepRange = "everyStepRange"
+	Named          = "named"
+)
+
+var messageKeys = []string{AtTime, At, Past, OnDayOfMonth, OnDayOfWeek, AndOnDayOfWeek, OrOnDayOfWeek,
+	InMonth, Every, EveryRange, EveryStep, EveryStepRange, Named}
+
+// Field holds the localized forms of one cron field name.
+type Field struct {
+	Name   string
+	Plural string
+	// Few is the plural after numbers like 2, 3 and 4 in languages that
+	// have one, see Catalog.PluralForm.
+	Few   string
+	Every string
+}
+
+type Catalog struct {
+	Minute     Field
+	Hour       Field
+	DayOfMonth Field
+	Month      Field
+	DayOfWeek  Field
+	DayOfWeeks [7]string
+	Months     [13]string
+	And        string
+	Separator  string
+	Ordinal    func(int) string
+	// PluralForm picks the plural of a field after the number n, Plural
+	// when nil.
+	PluralForm func(n int, field Field) string
+	Messages   map[string]string
+	templates  map[string]*template.Template
+}
+
+var catalogs = map[string]*Catalog{}
+
+// register compiles the messages of a built-in catalog. It runs from init, so
+// a catalog with a missing or broken message panics at start up instead of on
+// first use, and the catalogs are only read afterwards.
+func register(name string, catalog *Catalog) {
+	catalog.templates = map[string]*template.Template{}
+	for _, key := range messageKeys {
+		message, ok := catalog.Messages[key]
+		if !ok {
+			panic(fmt.Sprintf("Locale %q is missing message %q", name, key))
+		}
+		catalog.templates[key] = template.Must(template.New(key).Parse(message))
+	}
+	catalogs[name] = catalog
+}
+
+// Lookup returns the catalog for the given locale with its messages compiled.
+func Lookup(name string) (*Catalog, error) {
+	catalog, ok := catalogs[name]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Unsupported locale %q, should be one of: %v", name, Names()))
+	}
+	return catalog, nil
+}
+
+func Names() []string {
+	res := []string{}
+	for name := range catalogs {
+		res = append(res, name)
+	}
+	slices.Sort(res)
+	return res
+}
+
+func (c *Catalog) Template(key string) *template.Template {
+	return c.templates[key]
+}
+
+// PluralOf returns the plural of the field to write after the number n,
+// e.g. "co 3 miesiące" but "co 5 miesięcy" in Polish.
+func (c *Catalog) PluralOf(n int, field Field) string {
+	if c.PluralForm == nil {
+		return field.Plural
+	}
+	return c.PluralForm(n, field)
+}
+
+func (c *Catalog) MonthName(month int) string {
+	return c.Months[month]
+}
+
+func (c *Catalog) DayOfWeekName(day int) string {
+	return c.DayOfWeeks[day]
+}
@@ -0,0 +1,41 @@
+package locale
+
+import "fmt"
+
+func init() {
+	register("pl", &Catalog{
+		Minute:     Field{Name: "minuta", Plural: "minut", Few: "minuty", Every: "co minutę"},
+		Hour:       Field{Name: "godzina", Plural: "godzin", Few: "godziny", Every: "co godzinę"},
+		DayOfMonth: Field{Name: "dzień miesiąca", Plural: "dni miesiąca", Few: "dni miesiąca", Every: "każdy dzień miesiąca"},
+		Month:      Field{Name: "miesiąc", Plural: "miesięcy", Few: "miesiące", Every: "każdy miesiąc"},
+		DayOfWeek:  Field{Name: "dzień tygodnia", Plural: "dni tygodnia", Few: "dni tygodnia", Every: "każdy dzień tygodnia"},
+		DayOfWeeks: [7]string{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+		Months: [13]string{"", "styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec",
+			"lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"},
+		And:       " i ",
+		Separator: ", ",
+		Ordinal:   func(n int) string { return fmt.Sprintf("%d.", n) },
+		// 2-4, 22-24, ... take the few form, except 12-14.
+		PluralForm: func(n int, field Field) string {
+			if n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14) {
+				return field.Few
+			}
+			return field.Plural
+		},
+		Messages: map[string]string{
+			AtTime:         "O {{.}}",
+			At:             "{{.}}",
+			Past:           ", {{.}}",
+			OnDayOfMonth:   ", {{.}}",
+			OnDayOfWeek:    ", {{.}}",
+			AndOnDayOfWeek: " i {{.}}",
+			OrOnDayOfWeek:  " lub {{.}}",
+			InMonth:        ", {{.}}",
+			Every:          "{{.Every}}",
+			EveryRange:     "{{.Every}} {{.From}}–{{.To}}",
+			EveryStep:      "co {{.Step}} {{.Plural}}",
+			EveryStepRange: "co {{.Step}} {{.Plural}} {{.From}}–{{.To}}",
+			Named:          "{{.Name}} {{.Value}}",
+		},
+	})
+}
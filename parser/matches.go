@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DayPolicy says how day of month and day of week are combined when both
+// are restricted, e.g. in "0 0 1-7 * MON".
+type DayPolicy int
+
+const (
+	// DayPolicyOr fires when either day field matches, as Vixie cron does.
+	DayPolicyOr DayPolicy = iota
+	// DayPolicyAnd fires only when both day fields match, so "0 0 1-7 * MON"
+	// is the first Monday of the month.
+	DayPolicyAnd
+)
+
+func (d DayPolicy) String() string {
+	if d == DayPolicyAnd {
+		return "and"
+	}
+	return "or"
+}
+
+// ParseDayPolicy returns the policy named "or" or "and".
+func ParseDayPolicy(name string) (DayPolicy, error) {
+	switch name {
+	case "or":
+		return DayPolicyOr, nil
+	case "and":
+		return DayPolicyAnd, nil
+	}
+	return DayPolicyOr, errors.New(fmt.Sprintf("Unknown day policy %q, should be or or and", name))
+}
+
+// SetDayPolicy changes how the day fields are combined, DayPolicyOr is the
+// default.
+func (p *Parser) SetDayPolicy(policy DayPolicy) {
+	p.dayPolicy = policy
+}
+
+// Matches reports whether the expression fires in the minute of t. Seconds
+// and below are ignored, so 10:15:59 matches "15 10 * * *".
+func (p *Parser) Matches(t time.Time) bool {
+	_, month, _ := t.Date()
+	hour, minute, _ := t.Clock()
+	return p.minutes.Has(minute) &&
+		p.hours.Has(hour) &&
+		p.months.Has(int(month)) &&
+		p.dayMatches(t)
+}
+
+// Due reports whether a run is due at now: whether the expression fired
+// after lastRun, up to and including the minute of now. It also reports runs
+// missed in between, e.g. after a process was suspended. With a zero
+// lastRun only the minute of now is checked.
+func (p *Parser) Due(now, lastRun time.Time) bool {
+	if lastRun.IsZero() {
+		return p.Matches(now)
+	}
+
+	next := p.Next(lastRun)
+	return !next.IsZero() && !next.After(now)
+}
+
+// dayMatches checks day of month and day of week. When either of them is not
+// restricted (starts with an asterisk) both have to match; otherwise they are
+// combined as the day policy says.
+func (p *Parser) dayMatches(t time.Time) bool {
+	return p.dayMatchesOn(t.Day(), t.Weekday())
+}
+
+func (p *Parser) dayMatchesOn(day int, weekday time.Weekday) bool {
+	dayOfMonth := p.daysOfMonth.Has(day)
+	dayOfWeek := p.daysOfWeek.Has(int(weekday))
+
+	if !p.eitherDayMatches() {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// eitherDayMatches reports whether a day matches when only one of the day
+// fields matches it, i.e. both are restricted and the policy is OR.
+func (p *Parser) eitherDayMatches() bool {
+	return p.daysOfMonthRestricted && p.daysOfWeekRestricted && p.dayPolicy == DayPolicyOr
+}
@@ -0,0 +1,204 @@
+package parser
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestShouldMatchTimesOfExpression(t *testing.T) {
+	testScenarios := []struct {
+		input    string
+		time     time.Time
+		expected bool
+	}{
+		{"* * * * * cmd", time.Date(2026, time.October, 19, 10, 7, 0, 0, time.UTC), true},
+		{"*/15 0 1,15 * * cmd", time.Date(2026, time.October, 15, 0, 30, 0, 0, time.UTC), true},
+		{"*/15 0 1,15 * * cmd", time.Date(2026, time.October, 15, 0, 31, 0, 0, time.UTC), false},
+		{"*/15 0 1,15 * * cmd", time.Date(2026, time.October, 16, 0, 30, 0, 0, time.UTC), false},
+		{"0 9 * * MON-FRI cmd", time.Date(2026, time.October, 19, 9, 0, 0, 0, time.UTC), true},
+		{"0 9 * * MON-FRI cmd", time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC), false},
+		{"0 9 * JAN * cmd", time.Date(2026, time.October, 19, 9, 0, 0, 0, time.UTC), false},
+		{"15 10 * * * cmd", time.Date(2026, time.October, 19, 10, 15, 59, 999, time.UTC), true},
+		{"0 0 1 * MON cmd", time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC), true},
+		{"0 0 1 * MON cmd", time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC), true},
+		{"0 0 1 * MON cmd", time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC), false},
+		{"0 0 */2 * MON cmd", time.Date(2026, time.October, 26, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, scenario := range testScenarios {
+		schedule, err := Parse(scenario.input)
+		if err != nil {
+			t.Fatalf("Should not return error with proper input; %s", err)
+		}
+
+		if schedule.Matches(scenario.time) != scenario.expected {
+			t.Fatalf("Should match %q at %v: %v", scenario.input, scenario.time, scenario.expected)
+		}
+	}
+}
+
+func TestShouldMatchEveryNextRunTime(t *testing.T) {
+	schedule, err := Parse("*/7 */5 1-10,20 * MON,WED cmd")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	from := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	for _, run := range schedule.NextN(from, 50) {
+		if !schedule.Matches(run) {
+			t.Fatalf("Should match its own run time %v", run)
+		}
+	}
+}
+
+func TestShouldReportDueRuns(t *testing.T) {
+	schedule, err := Parse("0 * * * * cmd")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, time.October, 19, hour, minute, 0, 0, time.UTC)
+	}
+
+	testScenarios := []struct {
+		now      time.Time
+		lastRun  time.Time
+		expected bool
+		comment  string
+	}{
+		{at(10, 0), at(9, 0), true, "Should be due at fire time"},
+		{at(10, 30), at(10, 0), false, "Should not be due between fire times"},
+		{at(12, 30), at(10, 0), true, "Should be due after missed runs"},
+		{at(10, 0), at(10, 0), false, "Should not be due again in the minute of the last run"},
+		{at(10, 0), time.Time{}, true, "Should check only now without last run"},
+		{at(10, 1), time.Time{}, false, "Should check only now without last run"},
+	}
+
+	for _, scenario := range testScenarios {
+		if schedule.Due(scenario.now, scenario.lastRun) != scenario.expected {
+			t.Fatalf("%s; now: %v, last run: %v", scenario.comment, scenario.now, scenario.lastRun)
+		}
+	}
+}
+
+// matchesWithSliceScan is how matching worked before fields were stored as
+// bitsets and serves as the baseline for the benchmarks below.
+func matchesWithSliceScan(fields []Field, t time.Time) bool {
+	return slices.Contains(fields[0].Values, t.Minute()) &&
+		slices.Contains(fields[1].Values, t.Hour()) &&
+		slices.Contains(fields[2].Values, t.Day()) &&
+		slices.Contains(fields[3].Values, int(t.Month())) &&
+		slices.Contains(fields[4].Values, int(t.Weekday()))
+}
+
+func benchmarkSchedules(b *testing.B) []*Schedule {
+	inputs := []string{
+		"* * * * * cmd",
+		"*/15 0 1,15 * 1-5 cmd",
+		"59 23 31 12 * cmd",
+		"1-59/2 */3 * JAN-JUNE * cmd",
+	}
+
+	res := []*Schedule{}
+	for _, input := range inputs {
+		schedule, err := Parse(input)
+		if err != nil {
+			b.Fatalf("Should not return error with proper input; %s", err)
+		}
+		res = append(res, schedule)
+	}
+	return res
+}
+
+func BenchmarkMatches(b *testing.B) {
+	schedules := benchmarkSchedules(b)
+	t := time.Date(2026, time.December, 31, 23, 59, 0, 0, time.UTC)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, schedule := range schedules {
+			schedule.Matches(t)
+		}
+	}
+}
+
+func BenchmarkMatchesWithSliceScan(b *testing.B) {
+	fields := [][]Field{}
+	for _, schedule := range benchmarkSchedules(b) {
+		fields = append(fields, schedule.Fields())
+	}
+	t := time.Date(2026, time.December, 31, 23, 59, 0, 0, time.UTC)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, scheduleFields := range fields {
+			matchesWithSliceScan(scheduleFields, t)
+		}
+	}
+}
+
+func TestShouldCombineDayFieldsAsDayPolicySays(t *testing.T) {
+	schedule, err := Parse("0 0 1-7 * MON cmd")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	firstMonday := time.Date(2026, time.November, 2, 0, 0, 0, 0, time.UTC)
+	secondMonday := time.Date(2026, time.November, 9, 0, 0, 0, 0, time.UTC)
+	firstDay := time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+	if !schedule.Matches(firstMonday) || !schedule.Matches(secondMonday) || !schedule.Matches(firstDay) {
+		t.Fatalf("Should fire on either day field by default")
+	}
+
+	firstMondayOnly := schedule.WithDayPolicy(DayPolicyAnd)
+	if !firstMondayOnly.Matches(firstMonday) || firstMondayOnly.Matches(secondMonday) || firstMondayOnly.Matches(firstDay) {
+		t.Fatalf("Should fire only when both day fields match with AND policy")
+	}
+
+	next := firstMondayOnly.Next(firstMonday)
+	if !next.Equal(time.Date(2026, time.December, 7, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Should return next first Monday, actual: %v", next)
+	}
+
+	if schedule.DayPolicy() != DayPolicyOr {
+		t.Fatalf("Should not change the original schedule")
+	}
+}
+
+func TestShouldWarnWhenDayFieldsLikelyMeantAnd(t *testing.T) {
+	testScenarios := []struct {
+		input    string
+		expected string
+	}{
+		{"0 0 1-7 * MON cmd", `day of month: "1-7 MON" fires on either day field, use the AND day policy to fire only when both match, or MON#1 where the dialect supports it`},
+		{"0 0 22-28 * 5 cmd", `day of month: "22-28 5" fires on either day field, use the AND day policy to fire only when both match, or FRI#4 where the dialect supports it`},
+		{"0 0 8-14 * MON,TUE cmd", `day of month: "8-14 MON,TUE" fires on either day field, use the AND day policy to fire only when both match`},
+		{"0 0 1-7 * * cmd", ""},
+		{"0 0 2-8 * MON cmd", ""},
+		{"0 0 1,15 * MON cmd", ""},
+	}
+
+	for _, scenario := range testScenarios {
+		schedule, err := Parse(scenario.input)
+		if err != nil {
+			t.Fatalf("Should not return error with proper input; %s", err)
+		}
+
+		warnings := []string{}
+		for _, warning := range schedule.Warnings() {
+			warnings = append(warnings, warning.String())
+		}
+
+		if scenario.expected == "" && len(warnings) != 0 || scenario.expected != "" && !slices.Contains(warnings, scenario.expected) {
+			t.Fatalf("Should warn properly about %q, expected: %q, actual: %v", scenario.input, scenario.expected, warnings)
+		}
+	}
+
+	schedule, _ := Parse("0 0 1-7 * MON cmd")
+	if len(schedule.WithDayPolicy(DayPolicyAnd).Warnings()) != 0 {
+		t.Fatalf("Should not warn with AND day policy")
+	}
+}
@@ -0,0 +1,202 @@
+package parser
+
+import (
+	"cron_expression_parser/parser/consts"
+	"strings"
+	"time"
+)
+
+// searchLimit bounds the search for the next run, so expressions that can
+// never fire (e.g. "0 0 30 2 *") do not loop forever.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+// Next returns the first time after from at which the expression fires, or
+// the zero time when it does not fire within the next five years. Run times
+// are in the location of from. As in Vixie cron, when clocks go back only
+// expressions with a wildcard minute or hour fire again in the repeated
+// hour, and when clocks go forward the others fire at the first instant
+// after the gap if they would have fired in it.
+func (p *Parser) Next(from time.Time) time.Time {
+	next := p.next(from)
+	if p.isWildcardTime() {
+		return next
+	}
+
+	limit := next
+	if limit.IsZero() {
+		limit = from.Add(searchLimit)
+	}
+	for _, end := from.ZoneBounds(); !end.IsZero() && end.Before(limit); _, end = end.ZoneBounds() {
+		if p.firesInGapBefore(end) {
+			return end
+		}
+	}
+	return next
+}
+
+func (p *Parser) next(from time.Time) time.Time {
+	t := from.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+
+	for t.Before(limit) {
+		if !p.months.Has(int(t.Month())) {
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location()))
+			continue
+		}
+
+		if !p.dayMatches(t) {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location()))
+			continue
+		}
+
+		if !p.hours.Has(t.Hour()) {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location()))
+			continue
+		}
+
+		if !p.minutes.Has(t.Minute()) || (isRepeatedWallClock(t) && !p.isWildcardTime()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// forward returns next when it is after t. When clocks go forward, the wall
+// clock time next stands for may not exist and time.Date can normalize it
+// to a time before t, so then t moves to the start of its next hour in
+// absolute time instead and the fields are checked again from there. When
+// clocks go back, the first of the two times showing next is taken.
+func forward(t, next time.Time) time.Time {
+	if !next.After(t) {
+		return t.Add(time.Duration(60-t.Minute()) * time.Minute)
+	}
+	if earlier := next.Add(-time.Hour); earlier.After(t) && isRepeatedWallClock(next) {
+		return earlier
+	}
+	return next
+}
+
+// backward is forward for Prev: it returns prev, or the second of the two
+// times showing it, when it is before t, and otherwise the last minute of
+// the hour before t.
+func backward(t, prev time.Time) time.Time {
+	if !prev.Before(t) {
+		return t.Add(-time.Duration(t.Minute()+1) * time.Minute)
+	}
+	if later := prev.Add(time.Hour); later.Before(t) && isRepeatedWallClock(later) {
+		return later
+	}
+	return prev
+}
+
+// isRepeatedWallClock reports whether the wall clock already showed the time
+// of t an hour earlier, which happens when clocks go back.
+func isRepeatedWallClock(t time.Time) bool {
+	earlier := t.Add(-time.Hour)
+	return earlier.Hour() == t.Hour() && earlier.Day() == t.Day()
+}
+
+// isWildcardTime reports whether the minute or the hour starts with an
+// asterisk, what Vixie cron calls a wildcard job.
+func (p *Parser) isWildcardTime() bool {
+	return strings.HasPrefix(p.tokens[0], consts.ASTERIKS) || strings.HasPrefix(p.tokens[1], consts.ASTERIKS)
+}
+
+// firesInGapBefore reports whether the expression would have fired at one of
+// the wall clock times skipped when clocks went forward at end, the start of
+// a zone period.
+func (p *Parser) firesInGapBefore(end time.Time) bool {
+	_, before := end.Add(-time.Nanosecond).Zone()
+	_, after := end.Zone()
+	if after <= before {
+		return false
+	}
+
+	// The skipped wall clock times, written in UTC so they all exist.
+	wall := time.Date(end.Year(), end.Month(), end.Day(), end.Hour(), end.Minute(), 0, 0, time.UTC)
+	for t := wall.Add(-time.Duration(after-before) * time.Second); t.Before(wall); t = t.Add(time.Minute) {
+		if p.Matches(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextN returns up to n consecutive run times after from.
+func (p *Parser) NextN(from time.Time, n int) []time.Time {
+	res := []time.Time{}
+	for len(res) < n {
+		from = p.Next(from)
+		if from.IsZero() {
+			break
+		}
+		res = append(res, from)
+	}
+	return res
+}
+
+// Prev returns the last time before from at which the expression fired, or
+// the zero time when it did not fire within the previous five years. Like
+// Next, it skips the repeated hour when clocks go back unless the minute or
+// hour is a wildcard, and returns the first instant after a gap for runs
+// skipped when clocks go forward.
+func (p *Parser) Prev(from time.Time) time.Time {
+	prev := p.prev(from)
+	if p.isWildcardTime() {
+		return prev
+	}
+
+	start := prev
+	if start.IsZero() {
+		start = from.Add(-searchLimit)
+	}
+	for _, end := start.ZoneBounds(); !end.IsZero() && end.Before(from); _, end = end.ZoneBounds() {
+		if end.After(prev) && p.firesInGapBefore(end) {
+			prev = end
+		}
+	}
+	return prev
+}
+
+func (p *Parser) prev(from time.Time) time.Time {
+	t := from.Truncate(time.Minute)
+	if !t.Before(from) {
+		t = t.Add(-time.Minute)
+	}
+	limit := t.Add(-searchLimit)
+
+	for t.After(limit) {
+		if !p.months.Has(int(t.Month())) {
+			t = backward(t, time.Date(t.Year(), t.Month(), 1, 0, -1, 0, 0, t.Location()))
+			continue
+		}
+
+		if !p.dayMatches(t) {
+			t = backward(t, time.Date(t.Year(), t.Month(), t.Day(), 0, -1, 0, 0, t.Location()))
+			continue
+		}
+
+		if !p.hours.Has(t.Hour()) {
+			t = backward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), -1, 0, 0, t.Location()))
+			continue
+		}
+
+		if !p.minutes.Has(t.Minute()) || (isRepeatedWallClock(t) && !p.isWildcardTime()) {
+			t = t.Add(-time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// Between returns the run times after from and up to and including to.
+func (p *Parser) Between(from, to time.Time) []time.Time {
+	res := []time.Time{}
+	for t := p.Next(from); !t.IsZero() && !t.After(to); t = p.Next(t) {
+		res = append(res, t)
+	}
+	return res
+}
@@ -0,0 +1,244 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShouldReturnNextRunTime(t *testing.T) {
+	from := time.Date(2026, time.October, 19, 10, 7, 30, 0, time.UTC)
+
+	testScenarios := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"* * * * * cmd", time.Date(2026, time.October, 19, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * * cmd", time.Date(2026, time.October, 19, 10, 15, 0, 0, time.UTC)},
+		{"0 0 * * * cmd", time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 JAN * cmd", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * SAT cmd", time.Date(2026, time.October, 24, 9, 0, 0, 0, time.UTC)},
+		{"0 9 25 * SAT cmd", time.Date(2026, time.October, 24, 9, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 * cmd", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 * cmd", time.Time{}},
+	}
+
+	for _, scenario := range testScenarios {
+		parser := NewParser()
+		err := parser.Parse(scenario.input)
+		if err != nil {
+			t.Fatalf("Should not return error with proper input; %s", err)
+		}
+
+		next := parser.Next(from)
+		if !next.Equal(scenario.expected) {
+			t.Fatalf("Should return next run of %q, expected: %v, actual: %v", scenario.input, scenario.expected, next)
+		}
+	}
+}
+
+func TestShouldReturnNextNRunTimes(t *testing.T) {
+	parser := NewParser()
+	err := parser.Parse("30 2 * * * cmd")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	from := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	runs := parser.NextN(from, 3)
+	if len(runs) != 3 {
+		t.Fatalf("Should return 3 runs, actual: %v", runs)
+	}
+
+	for i, run := range runs {
+		expected := time.Date(2026, time.October, 20+i, 2, 30, 0, 0, time.UTC)
+		if !run.Equal(expected) {
+			t.Fatalf("Should return consecutive runs, expected: %v, actual: %v", expected, run)
+		}
+	}
+}
+
+func TestShouldFireOnceWhenClocksGoBack(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("No time zone data; %s", err)
+	}
+	from := time.Date(2026, time.November, 1, 0, 0, 0, 0, newYork)
+
+	fixed, _ := Parse("30 1 * * * cmd")
+	runs := fixed.NextN(from, 2)
+	if runs[0].Format(time.RFC3339) != "2026-11-01T01:30:00-04:00" || runs[1].Format(time.RFC3339) != "2026-11-02T01:30:00-05:00" {
+		t.Fatalf("Should run fixed time once, actual: %v", runs)
+	}
+
+	wildcard, _ := Parse("*/30 * * * * cmd")
+	runs = wildcard.Between(from.Add(time.Hour), from.Add(3*time.Hour))
+	if len(runs) != 4 {
+		t.Fatalf("Should run wildcard expression in the repeated hour too, actual: %v", runs)
+	}
+}
+
+func TestShouldReturnPreviousRunTime(t *testing.T) {
+	from := time.Date(2026, time.October, 19, 10, 7, 30, 0, time.UTC)
+
+	testScenarios := []struct {
+		input    string
+		from     time.Time
+		expected time.Time
+	}{
+		{"* * * * * cmd", from, time.Date(2026, time.October, 19, 10, 7, 0, 0, time.UTC)},
+		{"* * * * * cmd", time.Date(2026, time.October, 19, 10, 7, 0, 0, time.UTC), time.Date(2026, time.October, 19, 10, 6, 0, 0, time.UTC)},
+		{"*/15 * * * * cmd", from, time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)},
+		{"30 23 * * * cmd", from, time.Date(2026, time.October, 18, 23, 30, 0, 0, time.UTC)},
+		{"0 0 31 * * cmd", from, time.Date(2026, time.August, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * SAT cmd", from, time.Date(2026, time.October, 17, 9, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 * cmd", from, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 * cmd", from, time.Time{}},
+	}
+
+	for _, scenario := range testScenarios {
+		parser := NewParser()
+		err := parser.Parse(scenario.input)
+		if err != nil {
+			t.Fatalf("Should not return error with proper input; %s", err)
+		}
+
+		prev := parser.Prev(scenario.from)
+		if !prev.Equal(scenario.expected) {
+			t.Fatalf("Should return previous run of %q, expected: %v, actual: %v", scenario.input, scenario.expected, prev)
+		}
+	}
+}
+
+func TestShouldReturnRunTimesBetween(t *testing.T) {
+	parser := NewParser()
+	err := parser.Parse("0 */6 * * * cmd")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	from := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.October, 19, 18, 0, 0, 0, time.UTC)
+	runs := parser.Between(from, to)
+
+	expected := []time.Time{
+		time.Date(2026, time.October, 19, 6, 0, 0, 0, time.UTC),
+		time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC),
+		time.Date(2026, time.October, 19, 18, 0, 0, 0, time.UTC),
+	}
+	if len(runs) != len(expected) {
+		t.Fatalf("Should return runs after from up to to, expected: %v, actual: %v", expected, runs)
+	}
+	for i := range expected {
+		if !runs[i].Equal(expected[i]) {
+			t.Fatalf("Should return runs after from up to to, expected: %v, actual: %v", expected, runs)
+		}
+	}
+}
+
+func TestShouldSkipMissingHourWhenClocksGoForward(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("No time zone data; %s", err)
+	}
+
+	daily, _ := Parse("0 9 * * * cmd")
+	runs := daily.NextN(time.Date(2026, time.March, 7, 12, 0, 0, 0, newYork), 2)
+	if len(runs) != 2 || runs[0].Format(time.RFC3339) != "2026-03-08T09:00:00-04:00" || runs[1].Format(time.RFC3339) != "2026-03-09T09:00:00-04:00" {
+		t.Fatalf("Should find runs after the missing hour, actual: %v", runs)
+	}
+
+	prev := daily.Prev(time.Date(2026, time.March, 8, 8, 0, 0, 0, newYork))
+	if prev.Format(time.RFC3339) != "2026-03-07T09:00:00-05:00" {
+		t.Fatalf("Should find previous run before the missing hour, actual: %v", prev)
+	}
+
+	hourly, _ := Parse("15 * * * * cmd")
+	runs = hourly.NextN(time.Date(2026, time.March, 8, 1, 0, 0, 0, newYork), 2)
+	if runs[0].Format(time.RFC3339) != "2026-03-08T01:15:00-05:00" || runs[1].Format(time.RFC3339) != "2026-03-08T03:15:00-04:00" {
+		t.Fatalf("Should skip the missing hour, actual: %v", runs)
+	}
+
+	prev = hourly.Prev(time.Date(2026, time.March, 8, 3, 10, 0, 0, newYork))
+	if prev.Format(time.RFC3339) != "2026-03-08T01:15:00-05:00" {
+		t.Fatalf("Should skip the missing hour backwards, actual: %v", prev)
+	}
+}
+
+func TestShouldReturnPreviousRunOnceWhenClocksGoBack(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("No time zone data; %s", err)
+	}
+
+	fixed, _ := Parse("30 1 * * * cmd")
+	// 01:45 EST, the second time the wall clock shows 01:45 that night.
+	from := time.Date(2026, time.November, 1, 6, 45, 0, 0, time.UTC).In(newYork)
+	prev := fixed.Prev(from)
+	if prev.Format(time.RFC3339) != "2026-11-01T01:30:00-04:00" {
+		t.Fatalf("Should skip the repeated hour, actual: %v", prev)
+	}
+
+	wildcard, _ := Parse("*/30 * * * * cmd")
+	prev = wildcard.Prev(from)
+	if prev.Format(time.RFC3339) != "2026-11-01T01:30:00-05:00" {
+		t.Fatalf("Should run wildcard expression in the repeated hour, actual: %v", prev)
+	}
+}
+
+func TestShouldFireInFirstOfRepeatedHours(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skipf("No time zone data; %s", err)
+	}
+
+	fixed, _ := Parse("30 1 * * * cmd")
+	next := fixed.Next(time.Date(2026, time.October, 25, 0, 0, 0, 0, london))
+	if next.Format(time.RFC3339) != "2026-10-25T01:30:00+01:00" {
+		t.Fatalf("Should fire in the first 01:30 of the night, actual: %v", next)
+	}
+}
+
+func TestShouldTreatWildcardMinuteOrHourAsWildcardWhenClocksGoBack(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("No time zone data; %s", err)
+	}
+	from := time.Date(2026, time.November, 1, 0, 0, 0, 0, newYork)
+	to := from.Add(4 * time.Hour)
+
+	hourly, _ := Parse("0 * * * * cmd")
+	runs := hourly.Between(from, to)
+	if len(runs) != 4 || runs[0].Format(time.RFC3339) != "2026-11-01T01:00:00-04:00" || runs[1].Format(time.RFC3339) != "2026-11-01T01:00:00-05:00" {
+		t.Fatalf("Should fire in both 01:00 with a wildcard hour, actual: %v", runs)
+	}
+
+	everyMinute, _ := Parse("* 1 * * * cmd")
+	runs = everyMinute.Between(from, to)
+	if len(runs) != 120 || runs[60].Format(time.RFC3339) != "2026-11-01T01:00:00-05:00" {
+		t.Fatalf("Should fire in both 01 hours with a wildcard minute, actual: %d runs", len(runs))
+	}
+}
+
+func TestShouldFireAfterGapWhenClocksGoForward(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("No time zone data; %s", err)
+	}
+
+	fixed, _ := Parse("30 2 * * * cmd")
+	runs := fixed.NextN(time.Date(2026, time.March, 7, 12, 0, 0, 0, newYork), 2)
+	if len(runs) != 2 || runs[0].Format(time.RFC3339) != "2026-03-08T03:00:00-04:00" || runs[1].Format(time.RFC3339) != "2026-03-09T02:30:00-04:00" {
+		t.Fatalf("Should fire at the first instant after the gap, actual: %v", runs)
+	}
+
+	prev := fixed.Prev(time.Date(2026, time.March, 8, 12, 0, 0, 0, newYork))
+	if prev.Format(time.RFC3339) != "2026-03-08T03:00:00-04:00" {
+		t.Fatalf("Should find the run after the gap backwards, actual: %v", prev)
+	}
+
+	wildcard, _ := Parse("30 * * * * cmd")
+	next := wildcard.Next(time.Date(2026, time.March, 8, 1, 45, 0, 0, newYork))
+	if next.Format(time.RFC3339) != "2026-03-08T03:30:00-04:00" {
+		t.Fatalf("Should skip the gap with a wildcard hour, actual: %v", next)
+	}
+}
@@ -5,29 +5,58 @@ import (
 	"cron_expression_parser/parser/helpers"
 	"errors"
 	"fmt"
+	"os"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Parser struct {
-	minutes     []int
-	hours       []int
-	daysOfMonth []int
-	daysOfWeek  []int
-	months      []int
+	minutes     helpers.Bitset
+	hours       helpers.Bitset
+	daysOfMonth helpers.Bitset
+	daysOfWeek  helpers.Bitset
+	months      helpers.Bitset
 	command     string
+	tokens      []string
+
+	daysOfMonthRestricted bool
+	daysOfWeekRestricted  bool
+	dayPolicy             DayPolicy
+
+	warnings []Warning
 }
 
-var valueToParseMap = map[int]consts.Value{}
+// valueToParseMap describes the five time fields by their index. It is
+// built once and only read afterwards, so parsers can run concurrently.
+var valueToParseMap = map[int]consts.Value{
+	0: &consts.Minutes{Name: "minutes"},
+	1: &consts.Hours{Name: "hours"},
+	2: &consts.DayOfMonth{Name: "day of month"},
+	3: &consts.Month{Name: "month"},
+	4: &consts.DayOfWeek{Name: "day of week"},
+}
 
-func NewParser() *Parser {
-	valueToParseMap[0] = &consts.Minutes{Name: "minutes"}
-	valueToParseMap[1] = &consts.Hours{Name: "hours"}
-	valueToParseMap[2] = &consts.DayOfMonth{Name: "day of month"}
-	valueToParseMap[3] = &consts.Month{Name: "month"}
-	valueToParseMap[4] = &consts.DayOfWeek{Name: "day of week"}
+// FieldError is returned when one of the five time fields cannot be parsed.
+// Field is the index of the field, from 0 for minutes to 4 for day of week,
+// and Token is the field as written.
+type FieldError struct {
+	Field int
+	Name  string
+	Token string
+	Err   error
+}
 
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+func NewParser() *Parser {
 	return &Parser{}
 }
 
@@ -44,25 +73,19 @@ func (p *Parser) Parse(input string) error {
 	return nil
 }
 
-func (p *Parser) PrintCurrentCronExpression() {
-	printField("minute", p.minutes)
-	printField("hour", p.hours)
-	printField("day of month", p.daysOfMonth)
-	printField("month", p.months)
-	printField("day of week", p.daysOfWeek)
-	printStringField("command", p.command)
-}
-
-func printField(fieldName string, field []int) {
-	fmt.Printf("%-14s", fieldName)
-	for i := 0; i < len(field); i++ {
-		fmt.Printf("%d ", field[i])
+// ParseTimeFields parses an expression made of only the five time fields,
+// e.g. "30 2 * * *", leaving the command empty.
+func (p *Parser) ParseTimeFields(input string) error {
+	fields := strings.Fields(input)
+	if len(fields) != 5 {
+		return errors.New("Input is in wrong format, should be: '* * * * *'")
 	}
-	fmt.Printf("\n")
+	return p.performParse(append(fields, ""))
 }
 
-func printStringField(fieldName string, field string) {
-	fmt.Printf("%-14s%s", fieldName, field)
+func (p *Parser) PrintCurrentCronExpression() {
+	renderer := &TextRenderer{}
+	renderer.Render(os.Stdout, []Result{p.Result(time.Time{}, 0)})
 }
 
 func getSplitInput(input string) ([]string, error) {
@@ -80,31 +103,41 @@ func getSplitInput(input string) ([]string, error) {
 
 func (p *Parser) performParse(slicedInput []string) error {
 
-	for key, val := range valueToParseMap {
+	for key := 0; key < len(valueToParseMap); key++ {
+		val := valueToParseMap[key]
 		res, err := parsePart(slicedInput[key], val)
 		if err != nil {
-			return err
+			return &FieldError{Field: key, Name: val.GetName(), Token: slicedInput[key], Err: err}
 		}
 
 		if !isOutputValid(res, val) {
-			return errors.New(fmt.Sprintf("Wrong range for %s, range: %d-%d; should be with range: %d-%d",
+			err = errors.New(fmt.Sprintf("Wrong range for %s, range: %d-%d; should be with range: %d-%d",
 				val.GetName(), slices.Min(res), slices.Max(res), val.GetMinValue(), val.GetMaxValue()))
+			return &FieldError{Field: key, Name: val.GetName(), Token: slicedInput[key], Err: err}
 		}
 
 		switch key {
 		case 0:
-			p.minutes = res
+			p.minutes = helpers.NewBitset(res...)
 		case 1:
-			p.hours = res
+			p.hours = helpers.NewBitset(res...)
 		case 2:
-			p.daysOfMonth = res
+			p.daysOfMonth = helpers.NewBitset(res...)
 		case 3:
-			p.months = res
+			p.months = helpers.NewBitset(res...)
 		case 4:
-			p.daysOfWeek = res
+			p.daysOfWeek = helpers.NewBitset(res...)
 		}
 	}
 
+	p.warnings = []Warning{}
+	for key := 0; key < len(valueToParseMap); key++ {
+		p.warnings = append(p.warnings, lintRedundantItems(slicedInput[key], valueToParseMap[key])...)
+	}
+
+	p.tokens = slicedInput[:5]
+	p.daysOfMonthRestricted = !strings.HasPrefix(p.tokens[2], consts.ASTERIKS)
+	p.daysOfWeekRestricted = !strings.HasPrefix(p.tokens[4], consts.ASTERIKS)
 	command := slicedInput[5:]
 	p.command = strings.Join(command, " ")
 	return nil
@@ -126,6 +159,10 @@ func parsePart(inputPart string, partType consts.Value) ([]int, error) {
 		inputPart = strings.ReplaceAll(inputPart, key, val)
 	}
 
+	for key, val := range consts.MonthAliases {
+		inputPart = strings.ReplaceAll(inputPart, key, val)
+	}
+
 	for key, val := range consts.MonthToNum{
 		inputPart = strings.ReplaceAll(inputPart, key, val)
 	}
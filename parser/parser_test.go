@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -61,24 +62,24 @@ func TestShouldParseSimpleOneDigitValuesProperly(t *testing.T) {
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
 
-	if !reflect.DeepEqual(parser.minutes, []int{1}) {
-		t.Fatalf("Should parse minutes properly, expected: %v, actual: %v", []int{1}, parser.minutes)
+	if !reflect.DeepEqual(parser.minutes.Values(), []int{1}) {
+		t.Fatalf("Should parse minutes properly, expected: %v, actual: %v", []int{1}, parser.minutes.Values())
 	}
 
-	if !reflect.DeepEqual(parser.hours, []int{1}) {
-		t.Fatalf("Should parse hours properly, expected: %v, actual: %v", []int{1}, parser.hours)
+	if !reflect.DeepEqual(parser.hours.Values(), []int{1}) {
+		t.Fatalf("Should parse hours properly, expected: %v, actual: %v", []int{1}, parser.hours.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfMonth, []int{1}) {
-		t.Fatalf("Should parse days of month properly, expected: %v, actual: %v", []int{1}, parser.daysOfMonth)
+	if !reflect.DeepEqual(parser.daysOfMonth.Values(), []int{1}) {
+		t.Fatalf("Should parse days of month properly, expected: %v, actual: %v", []int{1}, parser.daysOfMonth.Values())
 	}
 
-	if !reflect.DeepEqual(parser.months, []int{1}) {
-		t.Fatalf("Should parse months properly, expected: %v, actual: %v", []int{1}, parser.months)
+	if !reflect.DeepEqual(parser.months.Values(), []int{1}) {
+		t.Fatalf("Should parse months properly, expected: %v, actual: %v", []int{1}, parser.months.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfWeek, []int{1}) {
-		t.Fatalf("Should parse days of week properly, expected: %v, actual: %v", []int{1}, parser.daysOfWeek)
+	if !reflect.DeepEqual(parser.daysOfWeek.Values(), []int{1}) {
+		t.Fatalf("Should parse days of week properly, expected: %v, actual: %v", []int{1}, parser.daysOfWeek.Values())
 	}
 }
 
@@ -114,24 +115,24 @@ func TestShouldParseAllValuesAsAsteriksAndReturnAllPossibleValues(t *testing.T)
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
 
-	if !reflect.DeepEqual(parser.minutes, allMinutes) {
-		t.Fatalf("Should parse asteriks properly for minutes, expected: %v, actual: %v", allMinutes, parser.minutes)
+	if !reflect.DeepEqual(parser.minutes.Values(), allMinutes) {
+		t.Fatalf("Should parse asteriks properly for minutes, expected: %v, actual: %v", allMinutes, parser.minutes.Values())
 	}
 
-	if !reflect.DeepEqual(parser.hours, allHours) {
-		t.Fatalf("Should parse asteriks properly for hours, expected: %v, actual: %v", allHours, parser.hours)
+	if !reflect.DeepEqual(parser.hours.Values(), allHours) {
+		t.Fatalf("Should parse asteriks properly for hours, expected: %v, actual: %v", allHours, parser.hours.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfMonth, allDays) {
-		t.Fatalf("Should parse asteriks properly for days of month, expected: %v, actual: %v", allDays, parser.daysOfMonth)
+	if !reflect.DeepEqual(parser.daysOfMonth.Values(), allDays) {
+		t.Fatalf("Should parse asteriks properly for days of month, expected: %v, actual: %v", allDays, parser.daysOfMonth.Values())
 	}
 
-	if !reflect.DeepEqual(parser.months, allMonths) {
-		t.Fatalf("Should parse asteriks properly for months, expected: %v, actual: %v", allMonths, parser.months)
+	if !reflect.DeepEqual(parser.months.Values(), allMonths) {
+		t.Fatalf("Should parse asteriks properly for months, expected: %v, actual: %v", allMonths, parser.months.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfWeek, allDaysOfWeek) {
-		t.Fatalf("Should parse asteriks properly for days of week, expected: %v, actual: %v", allDaysOfWeek, parser.daysOfWeek)
+	if !reflect.DeepEqual(parser.daysOfWeek.Values(), allDaysOfWeek) {
+		t.Fatalf("Should parse asteriks properly for days of week, expected: %v, actual: %v", allDaysOfWeek, parser.daysOfWeek.Values())
 	}
 }
 
@@ -143,24 +144,24 @@ func TestShouldParseListingValuesProperly(t *testing.T) {
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
 
-	if !reflect.DeepEqual(parser.minutes, expected) {
-		t.Fatalf("Should parse listing values properly for minutes, expected: %v, actual: %v", expected, parser.minutes)
+	if !reflect.DeepEqual(parser.minutes.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for minutes, expected: %v, actual: %v", expected, parser.minutes.Values())
 	}
 
-	if !reflect.DeepEqual(parser.hours, expected) {
-		t.Fatalf("Should parse listing values properly for hours, expected: %v, actual: %v", expected, parser.hours)
+	if !reflect.DeepEqual(parser.hours.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for hours, expected: %v, actual: %v", expected, parser.hours.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfMonth, expected) {
-		t.Fatalf("Should parse listing values properly for days of month, expected: %v, actual: %v", expected, parser.daysOfMonth)
+	if !reflect.DeepEqual(parser.daysOfMonth.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for days of month, expected: %v, actual: %v", expected, parser.daysOfMonth.Values())
 	}
 
-	if !reflect.DeepEqual(parser.months, expected) {
-		t.Fatalf("Should parse listing values properly for months, expected: %v, actual: %v", expected, parser.months)
+	if !reflect.DeepEqual(parser.months.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for months, expected: %v, actual: %v", expected, parser.months.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfWeek, expected) {
-		t.Fatalf("Should parse listing values properly for days of week, expected: %v, actual: %v", expected, parser.daysOfWeek)
+	if !reflect.DeepEqual(parser.daysOfWeek.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for days of week, expected: %v, actual: %v", expected, parser.daysOfWeek.Values())
 	}
 }
 
@@ -172,24 +173,24 @@ func TestShouldParseRangeValuesProperly(t *testing.T) {
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
 
-	if !reflect.DeepEqual(parser.minutes, expected) {
-		t.Fatalf("Should parse listing values properly for minutes, expected: %v, actual: %v", expected, parser.minutes)
+	if !reflect.DeepEqual(parser.minutes.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for minutes, expected: %v, actual: %v", expected, parser.minutes.Values())
 	}
 
-	if !reflect.DeepEqual(parser.hours, expected) {
-		t.Fatalf("Should parse listing values properly for hours, expected: %v, actual: %v", expected, parser.hours)
+	if !reflect.DeepEqual(parser.hours.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for hours, expected: %v, actual: %v", expected, parser.hours.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfMonth, expected) {
-		t.Fatalf("Should parse listing values properly for days of month, expected: %v, actual: %v", expected, parser.daysOfMonth)
+	if !reflect.DeepEqual(parser.daysOfMonth.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for days of month, expected: %v, actual: %v", expected, parser.daysOfMonth.Values())
 	}
 
-	if !reflect.DeepEqual(parser.months, expected) {
-		t.Fatalf("Should parse listing values properly for months, expected: %v, actual: %v", expected, parser.months)
+	if !reflect.DeepEqual(parser.months.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for months, expected: %v, actual: %v", expected, parser.months.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfWeek, expected) {
-		t.Fatalf("Should parse listing values properly for days of week, expected: %v, actual: %v", expected, parser.daysOfWeek)
+	if !reflect.DeepEqual(parser.daysOfWeek.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for days of week, expected: %v, actual: %v", expected, parser.daysOfWeek.Values())
 	}
 }
 
@@ -202,24 +203,24 @@ func TestShouldParseListingAndRangeValuesProperly(t *testing.T) {
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
 
-	if !reflect.DeepEqual(parser.minutes, expected) {
-		t.Fatalf("Should parse listing values properly for minutes, expected: %v, actual: %v", expected, parser.minutes)
+	if !reflect.DeepEqual(parser.minutes.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for minutes, expected: %v, actual: %v", expected, parser.minutes.Values())
 	}
 
-	if !reflect.DeepEqual(parser.hours, expected) {
-		t.Fatalf("Should parse listing values properly for hours, expected: %v, actual: %v", expected, parser.hours)
+	if !reflect.DeepEqual(parser.hours.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for hours, expected: %v, actual: %v", expected, parser.hours.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfMonth, expected) {
-		t.Fatalf("Should parse listing values properly for days of month, expected: %v, actual: %v", expected, parser.daysOfMonth)
+	if !reflect.DeepEqual(parser.daysOfMonth.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for days of month, expected: %v, actual: %v", expected, parser.daysOfMonth.Values())
 	}
 
-	if !reflect.DeepEqual(parser.months, expected) {
-		t.Fatalf("Should parse listing values properly for months, expected: %v, actual: %v", expected, parser.months)
+	if !reflect.DeepEqual(parser.months.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for months, expected: %v, actual: %v", expected, parser.months.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfWeek, expectedDayOfWeek) {
-		t.Fatalf("Should parse listing values properly for days of week, expected: %v, actual: %v", expectedDayOfWeek, parser.daysOfWeek)
+	if !reflect.DeepEqual(parser.daysOfWeek.Values(), expectedDayOfWeek) {
+		t.Fatalf("Should parse listing values properly for days of week, expected: %v, actual: %v", expectedDayOfWeek, parser.daysOfWeek.Values())
 	}
 }
 
@@ -237,24 +238,24 @@ func TestShouldParseStepValuesProperly(t *testing.T) {
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
 
-	if !reflect.DeepEqual(parser.minutes, expectedMinutes) {
-		t.Fatalf("Should parse listing values properly for minutes, expected: %v, actual: %v", expectedMinutes, parser.minutes)
+	if !reflect.DeepEqual(parser.minutes.Values(), expectedMinutes) {
+		t.Fatalf("Should parse listing values properly for minutes, expected: %v, actual: %v", expectedMinutes, parser.minutes.Values())
 	}
 
-	if !reflect.DeepEqual(parser.hours, expectedHours) {
-		t.Fatalf("Should parse listing values properly for hours, expected: %v, actual: %v", expectedHours, parser.hours)
+	if !reflect.DeepEqual(parser.hours.Values(), expectedHours) {
+		t.Fatalf("Should parse listing values properly for hours, expected: %v, actual: %v", expectedHours, parser.hours.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfMonth, expectedDaysOfMonth) {
-		t.Fatalf("Should parse listing values properly for days of month, expected: %v, actual: %v", expectedDaysOfMonth, parser.daysOfMonth)
+	if !reflect.DeepEqual(parser.daysOfMonth.Values(), expectedDaysOfMonth) {
+		t.Fatalf("Should parse listing values properly for days of month, expected: %v, actual: %v", expectedDaysOfMonth, parser.daysOfMonth.Values())
 	}
 
-	if !reflect.DeepEqual(parser.months, expectedMonth) {
-		t.Fatalf("Should parse listing values properly for months, expected: %v, actual: %v", expectedMonth, parser.months)
+	if !reflect.DeepEqual(parser.months.Values(), expectedMonth) {
+		t.Fatalf("Should parse listing values properly for months, expected: %v, actual: %v", expectedMonth, parser.months.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfWeek, expectedDayOfWeek) {
-		t.Fatalf("Should parse listing values properly for days of week, expected: %v, actual: %v", expectedDayOfWeek, parser.daysOfWeek)
+	if !reflect.DeepEqual(parser.daysOfWeek.Values(), expectedDayOfWeek) {
+		t.Fatalf("Should parse listing values properly for days of week, expected: %v, actual: %v", expectedDayOfWeek, parser.daysOfWeek.Values())
 	}
 }
 
@@ -267,24 +268,24 @@ func TestShouldParseRangeWithStepValuesProperly(t *testing.T) {
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
 
-	if !reflect.DeepEqual(parser.minutes, expected) {
-		t.Fatalf("Should parse listing values properly for minutes, expected: %v, actual: %v", expected, parser.minutes)
+	if !reflect.DeepEqual(parser.minutes.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for minutes, expected: %v, actual: %v", expected, parser.minutes.Values())
 	}
 
-	if !reflect.DeepEqual(parser.hours, expected) {
-		t.Fatalf("Should parse listing values properly for hours, expected: %v, actual: %v", expected, parser.hours)
+	if !reflect.DeepEqual(parser.hours.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for hours, expected: %v, actual: %v", expected, parser.hours.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfMonth, expected) {
-		t.Fatalf("Should parse listing values properly for days of month, expected: %v, actual: %v", expected, parser.daysOfMonth)
+	if !reflect.DeepEqual(parser.daysOfMonth.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for days of month, expected: %v, actual: %v", expected, parser.daysOfMonth.Values())
 	}
 
-	if !reflect.DeepEqual(parser.months, expected) {
-		t.Fatalf("Should parse listing values properly for months, expected: %v, actual: %v", expected, parser.months)
+	if !reflect.DeepEqual(parser.months.Values(), expected) {
+		t.Fatalf("Should parse listing values properly for months, expected: %v, actual: %v", expected, parser.months.Values())
 	}
 
-	if !reflect.DeepEqual(parser.daysOfWeek, expectedDayOfWeek) {
-		t.Fatalf("Should parse listing values properly for days of week, expected: %v, actual: %v", expectedDayOfWeek, parser.daysOfWeek)
+	if !reflect.DeepEqual(parser.daysOfWeek.Values(), expectedDayOfWeek) {
+		t.Fatalf("Should parse listing values properly for days of week, expected: %v, actual: %v", expectedDayOfWeek, parser.daysOfWeek.Values())
 	}
 }
 
@@ -353,22 +354,22 @@ func TestShouldParseProperlyExample(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
-	if reflect.DeepEqual(parser.minutes, []int{0, 15, 30, 45}) != true {
+	if reflect.DeepEqual(parser.minutes.Values(), []int{0, 15, 30, 45}) != true {
 		t.Fatalf("Should parse minutes properly")
 	}
-	if reflect.DeepEqual(parser.hours, []int{0}) != true {
+	if reflect.DeepEqual(parser.hours.Values(), []int{0}) != true {
 		t.Fatalf("Should parse hours properly")
 	}
 
-	if reflect.DeepEqual(parser.daysOfMonth, []int{1, 15}) != true {
+	if reflect.DeepEqual(parser.daysOfMonth.Values(), []int{1, 15}) != true {
 		t.Fatalf("Should parse days of month properly")
 	}
 
-	if reflect.DeepEqual(parser.months, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}) != true {
+	if reflect.DeepEqual(parser.months.Values(), []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}) != true {
 		t.Fatalf("Should parse months properly")
 	}
 
-	if reflect.DeepEqual(parser.daysOfWeek, []int{1, 2, 3, 4, 5}) != true {
+	if reflect.DeepEqual(parser.daysOfWeek.Values(), []int{1, 2, 3, 4, 5}) != true {
 		t.Fatalf("Should parse days of week properly")
 	}
 
@@ -384,7 +385,7 @@ func TestShouldParseProperlyDaysAsString(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
-	if reflect.DeepEqual(parser.daysOfWeek, []int{1}) != true {
+	if reflect.DeepEqual(parser.daysOfWeek.Values(), []int{1}) != true {
 		t.Fatalf("Should parse minutes properly")
 	}
 }
@@ -396,7 +397,7 @@ func TestShouldParseProperlyDaysAsString2(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
-	if reflect.DeepEqual(parser.daysOfWeek, []int{0}) != true {
+	if reflect.DeepEqual(parser.daysOfWeek.Values(), []int{0}) != true {
 		t.Fatalf("Should parse minutes properly")
 	}
 }
@@ -408,7 +409,7 @@ func TestShouldParseProperlyDaysAsStringInRange(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
-	if reflect.DeepEqual(parser.daysOfWeek, []int{1, 2, 3, 4, 5}) != true {
+	if reflect.DeepEqual(parser.daysOfWeek.Values(), []int{1, 2, 3, 4, 5}) != true {
 		t.Fatalf("Should parse minutes properly")
 	}
 }
@@ -420,7 +421,7 @@ func TestShouldParseProperlyDaysAsStringWithCommas(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
-	if reflect.DeepEqual(parser.daysOfWeek, []int{1, 5}) != true {
+	if reflect.DeepEqual(parser.daysOfWeek.Values(), []int{1, 5}) != true {
 		t.Fatalf("Should parse minutes properly")
 	}
 }
@@ -432,7 +433,31 @@ func TestShouldParseProperlyMonthAsString(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Should not return error with proper input; %s", err)
 	}
-	if reflect.DeepEqual(parser.months, []int{1, 2, 3}) != true {
+	if reflect.DeepEqual(parser.months.Values(), []int{1, 2, 3}) != true {
 		t.Fatalf("Should parse minutes properly")
 	}
 }
+
+func TestShouldReturnFieldErrorForInvalidField(t *testing.T) {
+	parser := NewParser()
+	err := parser.Parse("*/15 25 1,15 JAN-MAR abc /usr/bin/find")
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("Should return FieldError, actual: %v", err)
+	}
+
+	if fieldErr.Field != 1 || fieldErr.Name != "hours" || fieldErr.Token != "25" {
+		t.Fatalf("Should point at the first invalid field, actual: %+v", fieldErr)
+	}
+}
+
+func TestShouldReturnErrorForZeroStepAndHugeRange(t *testing.T) {
+	for _, input := range []string{"*/0 * * * * cmd", "1-5/0 * * * * cmd", "0-99999999999 * * * * cmd"} {
+		parser := NewParser()
+		err := parser.Parse(input)
+		if err == nil {
+			t.Fatalf("Should return error for %q", input)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Renderer writes parsed expressions in one output format. A crontab file
+// renders as several results, a single expression as one.
+type Renderer interface {
+	Render(w io.Writer, results []Result) error
+}
+
+type TextRenderer struct{}
+
+type JSONRenderer struct {
+	Indent string
+}
+
+type YAMLRenderer struct{}
+
+type TOMLRenderer struct{}
+
+type CSVRenderer struct{}
+
+var textFieldNames = []string{"minute", "hour", "day of month", "month", "day of week"}
+
+// NewRenderer returns the renderer for one of: text, json, yaml, toml, csv.
+func NewRenderer(format string) (Renderer, error) {
+	switch format {
+	case "text":
+		return &TextRenderer{}, nil
+	case "json":
+		return &JSONRenderer{Indent: "  "}, nil
+	case "yaml":
+		return &YAMLRenderer{}, nil
+	case "toml":
+		return &TOMLRenderer{}, nil
+	case "csv":
+		return &CSVRenderer{}, nil
+	}
+	return nil, errors.New(fmt.Sprintf("Unknown output format %q, should be one of: text, json, yaml, toml, csv", format))
+}
+
+func (r *TextRenderer) Render(w io.Writer, results []Result) error {
+	for i, res := range results {
+		if i > 0 {
+			fmt.Fprint(w, "\n\n")
+		}
+		for j, field := range res.Fields {
+			fmt.Fprintf(w, "%-14s", textFieldNames[j])
+			for _, value := range field.Values {
+				fmt.Fprintf(w, "%d ", value)
+			}
+			fmt.Fprint(w, "\n")
+		}
+		fmt.Fprintf(w, "%-14s%s", "command", res.Command)
+		for _, run := range res.NextRuns {
+			fmt.Fprintf(w, "\n%-14s%s", "next run", run.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// Render writes an array of results, also for a single expression, so the
+// output has the same shape whatever the input.
+func (r *JSONRenderer) Render(w io.Writer, results []Result) error {
+	res, err := json.MarshalIndent(results, "", r.Indent)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", res)
+	return err
+}
+
+func (r *YAMLRenderer) Render(w io.Writer, results []Result) error {
+	for i, res := range results {
+		if i > 0 {
+			fmt.Fprintln(w, "---")
+		}
+		fmt.Fprintf(w, "schemaVersion: %d\n", ResultSchemaVersion)
+		fmt.Fprintf(w, "expression: %s\n", strconv.Quote(res.Expression))
+		fmt.Fprintln(w, "fields:")
+		for _, field := range res.Fields {
+			fmt.Fprintf(w, "  - name: %s\n", field.Name)
+			fmt.Fprintf(w, "    raw: %s\n", strconv.Quote(field.Raw))
+			fmt.Fprintf(w, "    values: [%s]\n", joinValues(field.Values, ", "))
+		}
+		fmt.Fprintf(w, "command: %s\n", strconv.Quote(res.Command))
+		if len(res.NextRuns) > 0 {
+			fmt.Fprintln(w, "nextRuns:")
+			for _, run := range res.NextRuns {
+				fmt.Fprintf(w, "  - %s\n", run.Format(time.RFC3339))
+			}
+		}
+	}
+	return nil
+}
+
+// Render writes an array of [[results]] tables, also for a single
+// expression, so the document has the same shape for any input.
+func (r *TOMLRenderer) Render(w io.Writer, results []Result) error {
+	fmt.Fprintf(w, "schemaVersion = %d\n", ResultSchemaVersion)
+
+	for _, res := range results {
+		fmt.Fprintln(w, "\n[[results]]")
+		fmt.Fprintf(w, "expression = %s\n", tomlString(res.Expression))
+		fmt.Fprintf(w, "command = %s\n", tomlString(res.Command))
+		if len(res.NextRuns) > 0 {
+			runs := []string{}
+			for _, run := range res.NextRuns {
+				runs = append(runs, run.Format(time.RFC3339))
+			}
+			fmt.Fprintf(w, "nextRuns = [%s]\n", strings.Join(runs, ", "))
+		}
+		for _, field := range res.Fields {
+			fmt.Fprintln(w, "\n[[results.fields]]")
+			fmt.Fprintf(w, "name = %s\n", tomlString(field.Name))
+			fmt.Fprintf(w, "raw = %s\n", tomlString(field.Raw))
+			fmt.Fprintf(w, "values = [%s]\n", joinValues(field.Values, ", "))
+		}
+	}
+	return nil
+}
+
+// tomlString quotes s as a TOML basic string. TOML has fewer escapes than Go,
+// so strconv.Quote would write invalid ones like \a or \x01.
+func tomlString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u%04X`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// Render writes one row per result, with expanded values separated by
+// spaces so every field fits in one cell.
+func (r *CSVRenderer) Render(w io.Writer, results []Result) error {
+	writer := csv.NewWriter(w)
+	header := append([]string{"expression", "command"}, resultFieldNames...)
+	err := writer.Write(append(header, "nextRuns"))
+	if err != nil {
+		return err
+	}
+
+	for _, res := range results {
+		row := []string{res.Expression, res.Command}
+		for _, field := range res.Fields {
+			row = append(row, joinValues(field.Values, " "))
+		}
+
+		runs := []string{}
+		for _, run := range res.NextRuns {
+			runs = append(runs, run.Format(time.RFC3339))
+		}
+		err = writer.Write(append(row, strings.Join(runs, " ")))
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func joinValues(values []int, separator string) string {
+	res := []string{}
+	for _, value := range values {
+		res = append(res, strconv.Itoa(value))
+	}
+	return strings.Join(res, separator)
+}
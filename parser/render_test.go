@@ -0,0 +1,200 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func renderExample(t *testing.T, format string, inputs ...string) string {
+	renderer, err := NewRenderer(format)
+	if err != nil {
+		t.Fatalf("Should return renderer for %s; %s", format, err)
+	}
+
+	results := []Result{}
+	from := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	for _, input := range inputs {
+		parser := NewParser()
+		err := parser.Parse(input)
+		if err != nil {
+			t.Fatalf("Should not return error with proper input; %s", err)
+		}
+		results = append(results, parser.Result(from, 1))
+	}
+
+	var out bytes.Buffer
+	err = renderer.Render(&out, results)
+	if err != nil {
+		t.Fatalf("Should render %s; %s", format, err)
+	}
+	return out.String()
+}
+
+func TestShouldRenderEveryOutputFormat(t *testing.T) {
+	testScenarios := []struct {
+		format   string
+		expected string
+	}{
+		{"text", `minute        0 
+hour          0 
+day of month  1 15 
+month         1 
+day of week   1 2 
+command       cmd
+next run      2027-01-01T00:00:00Z`},
+		{"json", `[
+  {
+    "schemaVersion": 2,
+    "expression": "0 0 1,15 1 1-2",
+    "fields": [
+      {
+        "name": "minute",
+        "raw": "0",
+        "values": [
+          0
+        ]
+      },
+      {
+        "name": "hour",
+        "raw": "0",
+        "values": [
+          0
+        ]
+      },
+      {
+        "name": "dayOfMonth",
+        "raw": "1,15",
+        "values": [
+          1,
+          15
+        ]
+      },
+      {
+        "name": "month",
+        "raw": "1",
+        "values": [
+          1
+        ]
+      },
+      {
+        "name": "dayOfWeek",
+        "raw": "1-2",
+        "values": [
+          1,
+          2
+        ]
+      }
+    ],
+    "command": "cmd",
+    "nextRuns": [
+      "2027-01-01T00:00:00Z"
+    ]
+  }
+]
+`},
+		{"yaml", `schemaVersion: 2
+expression: "0 0 1,15 1 1-2"
+fields:
+  - name: minute
+    raw: "0"
+    values: [0]
+  - name: hour
+    raw: "0"
+    values: [0]
+  - name: dayOfMonth
+    raw: "1,15"
+    values: [1, 15]
+  - name: month
+    raw: "1"
+    values: [1]
+  - name: dayOfWeek
+    raw: "1-2"
+    values: [1, 2]
+command: "cmd"
+nextRuns:
+  - 2027-01-01T00:00:00Z
+`},
+		{"toml", `schemaVersion = 2
+
+[[results]]
+expression = "0 0 1,15 1 1-2"
+command = "cmd"
+nextRuns = [2027-01-01T00:00:00Z]
+
+[[results.fields]]
+name = "minute"
+raw = "0"
+values = [0]
+
+[[results.fields]]
+name = "hour"
+raw = "0"
+values = [0]
+
+[[results.fields]]
+name = "dayOfMonth"
+raw = "1,15"
+values = [1, 15]
+
+[[results.fields]]
+name = "month"
+raw = "1"
+values = [1]
+
+[[results.fields]]
+name = "dayOfWeek"
+raw = "1-2"
+values = [1, 2]
+`},
+		{"csv", `expression,command,minute,hour,dayOfMonth,month,dayOfWeek,nextRuns
+"0 0 1,15 1 1-2",cmd,0,0,1 15,1,1 2,2027-01-01T00:00:00Z
+`},
+	}
+
+	for _, scenario := range testScenarios {
+		out := renderExample(t, scenario.format, "0 0 1,15 1 1-2 cmd")
+		if out != scenario.expected {
+			t.Fatalf("Should render %s properly, expected:\n%s\nactual:\n%s", scenario.format, scenario.expected, out)
+		}
+	}
+}
+
+func TestShouldRenderSeveralResults(t *testing.T) {
+	expected := `expression,command,minute,hour,dayOfMonth,month,dayOfWeek,nextRuns
+0 0 1 1 *,first,0,0,1,1,0 1 2 3 4 5 6,2027-01-01T00:00:00Z
+30 12 * * *,second,30,12,1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31,1 2 3 4 5 6 7 8 9 10 11 12,0 1 2 3 4 5 6,2026-10-19T12:30:00Z
+`
+
+	out := renderExample(t, "csv", "0 0 1 1 * first", "30 12 * * * second")
+	if out != expected {
+		t.Fatalf("Should render a row per result, expected:\n%s\nactual:\n%s", expected, out)
+	}
+}
+
+func TestShouldRenderSeveralResultsAsJSONArray(t *testing.T) {
+	for _, inputs := range [][]string{{"0 0 1 1 * first"}, {"0 0 1 1 * first", "30 12 * * * second"}} {
+		out := renderExample(t, "json", inputs...)
+		results := []map[string]any{}
+		err := json.Unmarshal([]byte(out), &results)
+		if err != nil || len(results) != len(inputs) {
+			t.Fatalf("Should render %d results as an array, actual:\n%s", len(inputs), out)
+		}
+	}
+}
+
+func TestShouldEscapeTOMLStrings(t *testing.T) {
+	expected := `"bell\u0007 tab\t quote\" backslash\\ del\u007F é"`
+	actual := tomlString("bell\a tab\t quote\" backslash\\ del\x7f é")
+	if actual != expected {
+		t.Fatalf("Should escape TOML string, expected: %s, actual: %s", expected, actual)
+	}
+}
+
+func TestShouldReturnErrorForUnknownOutputFormat(t *testing.T) {
+	_, err := NewRenderer("xml")
+	if err == nil {
+		t.Fatalf("Should return error for unknown output format")
+	}
+}
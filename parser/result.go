@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// ResultSchemaVersion is bumped whenever the rendered form of Result changes
+// in a way that is not backwards compatible. Version 2 always renders JSON
+// as an array and TOML as [[results]] tables.
+const ResultSchemaVersion = 2
+
+// Result is a parsed expression in a form meant for other programs.
+type Result struct {
+	Expression string
+	Fields     []FieldResult
+	Command    string
+	NextRuns   []time.Time
+}
+
+type FieldResult struct {
+	Name   string `json:"name"`
+	Raw    string `json:"raw"`
+	Values []int  `json:"values"`
+}
+
+var resultFieldNames = []string{"minute", "hour", "dayOfMonth", "month", "dayOfWeek"}
+
+// Result returns the parsed expression together with its next runs after
+// from. With nextRuns set to 0 no run times are computed.
+func (p *Parser) Result(from time.Time, nextRuns int) Result {
+	values := [][]int{p.minutes.Values(), p.hours.Values(), p.daysOfMonth.Values(), p.months.Values(), p.daysOfWeek.Values()}
+
+	res := Result{
+		Expression: strings.Join(p.tokens, " "),
+		Command:    p.command,
+		NextRuns:   p.NextN(from, nextRuns),
+	}
+	for i, name := range resultFieldNames {
+		res.Fields = append(res.Fields, FieldResult{Name: name, Raw: p.tokens[i], Values: values[i]})
+	}
+	return res
+}
+
+type jsonResult struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	Expression    string        `json:"expression"`
+	Fields        []FieldResult `json:"fields"`
+	Command       string        `json:"command"`
+	NextRuns      []string      `json:"nextRuns,omitempty"`
+}
+
+func (r Result) MarshalJSON() ([]byte, error) {
+	nextRuns := []string{}
+	for _, run := range r.NextRuns {
+		nextRuns = append(nextRuns, run.Format(time.RFC3339))
+	}
+
+	return json.Marshal(jsonResult{
+		SchemaVersion: ResultSchemaVersion,
+		Expression:    r.Expression,
+		Fields:        r.Fields,
+		Command:       r.Command,
+		NextRuns:      nextRuns,
+	})
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestShouldMarshalResultToVersionedJSON(t *testing.T) {
+	expected := `{"schemaVersion":2,"expression":"*/30 0 1,15 * 1-2","fields":[` +
+		`{"name":"minute","raw":"*/30","values":[0,30]},` +
+		`{"name":"hour","raw":"0","values":[0]},` +
+		`{"name":"dayOfMonth","raw":"1,15","values":[1,15]},` +
+		`{"name":"month","raw":"*","values":[1,2,3,4,5,6,7,8,9,10,11,12]},` +
+		`{"name":"dayOfWeek","raw":"1-2","values":[1,2]}],` +
+		`"command":"/usr/bin/find -x","nextRuns":["2026-10-20T00:00:00Z","2026-10-20T00:30:00Z"]}`
+
+	parser := NewParser()
+	err := parser.Parse("*/30 0 1,15 * 1-2 /usr/bin/find -x")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	from := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	res, err := json.Marshal(parser.Result(from, 2))
+	if err != nil {
+		t.Fatalf("Should marshal result; %s", err)
+	}
+
+	if string(res) != expected {
+		t.Fatalf("Should marshal result properly, expected: %s, actual: %s", expected, res)
+	}
+}
+
+func TestShouldOmitNextRunsWhenNotRequested(t *testing.T) {
+	parser := NewParser()
+	err := parser.Parse("* * * * * cmd")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	decoded := map[string]any{}
+	res, _ := json.Marshal(parser.Result(time.Now(), 0))
+	err = json.Unmarshal(res, &decoded)
+	if err != nil {
+		t.Fatalf("Should marshal valid json; %s", err)
+	}
+
+	if _, ok := decoded["nextRuns"]; ok {
+		t.Fatalf("Should omit next runs, actual: %s", res)
+	}
+}
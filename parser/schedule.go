@@ -0,0 +1,219 @@
+package parser
+
+import (
+	"cron_expression_parser/parser/consts"
+	"cron_expression_parser/parser/helpers"
+	"strings"
+	"time"
+)
+
+// Schedule is a read-only view of a parsed expression. Unlike Parser it
+// does not change when another expression is parsed.
+type Schedule struct {
+	parser Parser
+}
+
+// Field is one of the five time fields of a schedule together with its
+// metadata (name and allowed range).
+type Field struct {
+	consts.Value
+	Raw    string
+	Values []int
+	Bits   helpers.Bitset
+}
+
+// Parse parses the input and returns its schedule.
+func Parse(input string) (*Schedule, error) {
+	parser := NewParser()
+	err := parser.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return parser.Schedule(), nil
+}
+
+// ParseTimeFields parses an expression without a command, see
+// Parser.ParseTimeFields.
+func ParseTimeFields(input string) (*Schedule, error) {
+	parser := NewParser()
+	err := parser.ParseTimeFields(input)
+	if err != nil {
+		return nil, err
+	}
+	return parser.Schedule(), nil
+}
+
+// Schedule returns the last parsed expression.
+func (p *Parser) Schedule() *Schedule {
+	return &Schedule{parser: *p}
+}
+
+func (s *Schedule) Minutes() []int {
+	return s.parser.minutes.Values()
+}
+
+func (s *Schedule) Hours() []int {
+	return s.parser.hours.Values()
+}
+
+func (s *Schedule) DaysOfMonth() []int {
+	return s.parser.daysOfMonth.Values()
+}
+
+func (s *Schedule) Months() []int {
+	return s.parser.months.Values()
+}
+
+func (s *Schedule) DaysOfWeek() []int {
+	return s.parser.daysOfWeek.Values()
+}
+
+func (s *Schedule) Command() string {
+	return s.parser.command
+}
+
+// Expression returns the five time fields as they were written.
+func (s *Schedule) Expression() string {
+	return strings.Join(s.parser.tokens, " ")
+}
+
+// Fields returns minutes, hours, day of month, month and day of week in
+// that order.
+func (s *Schedule) Fields() []Field {
+	bits := []helpers.Bitset{s.parser.minutes, s.parser.hours, s.parser.daysOfMonth, s.parser.months, s.parser.daysOfWeek}
+
+	res := []Field{}
+	for i, fieldBits := range bits {
+		res = append(res, Field{Value: fieldValue(i), Raw: s.parser.tokens[i], Values: fieldBits.Values(), Bits: fieldBits})
+	}
+	return res
+}
+
+// fieldValue returns a new description of the field at index i, so callers
+// changing it do not change the one the parser uses.
+func fieldValue(i int) consts.Value {
+	switch i {
+	case 0:
+		return &consts.Minutes{Name: valueToParseMap[i].GetName()}
+	case 1:
+		return &consts.Hours{Name: valueToParseMap[i].GetName()}
+	case 2:
+		return &consts.DayOfMonth{Name: valueToParseMap[i].GetName()}
+	case 3:
+		return &consts.Month{Name: valueToParseMap[i].GetName()}
+	}
+	return &consts.DayOfWeek{Name: valueToParseMap[i].GetName()}
+}
+
+func (s *Schedule) DayPolicy() DayPolicy {
+	return s.parser.dayPolicy
+}
+
+// WithDayPolicy returns a copy of the schedule that combines the day fields
+// as the policy says.
+func (s *Schedule) WithDayPolicy(policy DayPolicy) *Schedule {
+	res := &Schedule{parser: s.parser}
+	res.parser.SetDayPolicy(policy)
+	return res
+}
+
+// EitherDayMatches reports whether the schedule fires on days matching only
+// one of the day fields, which is the case when both are restricted and
+// the day policy is OR.
+func (s *Schedule) EitherDayMatches() bool {
+	return s.parser.eitherDayMatches()
+}
+
+// Equivalent reports whether both schedules fire at the same times. The
+// days are compared as they are matched, so "0 0 1-31 * 1" is equivalent to
+// "0 0 * * *" as either day field matching is enough. Whether the minute or
+// hour is written with an asterisk is not compared, although it decides
+// whether a run repeats when clocks go back.
+func (s *Schedule) Equivalent(other *Schedule) bool {
+	fields, otherFields := s.Fields(), other.Fields()
+	for _, i := range []int{0, 1, 3} {
+		if fields[i].Bits != otherFields[i].Bits {
+			return false
+		}
+	}
+
+	for _, month := range s.parser.months.Values() {
+		for day := 1; day <= maxDaysIn(month); day++ {
+			for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+				if s.parser.dayMatchesOn(day, weekday) != other.parser.dayMatchesOn(day, weekday) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// maxDaysIn returns the number of days of the month in a leap year.
+func maxDaysIn(month int) int {
+	return time.Date(2024, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func (s *Schedule) Warnings() []Warning {
+	return s.parser.Warnings()
+}
+
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.parser.Matches(t)
+}
+
+func (s *Schedule) FiresOn(t time.Time) bool {
+	return s.parser.FiresOn(t)
+}
+
+func (s *Schedule) RunsPerDay() int {
+	return s.parser.RunsPerDay()
+}
+
+func (s *Schedule) RunsInHour(hour int) int {
+	return s.parser.RunsInHour(hour)
+}
+
+func (s *Schedule) Due(now, lastRun time.Time) bool {
+	return s.parser.Due(now, lastRun)
+}
+
+func (s *Schedule) Next(from time.Time) time.Time {
+	return s.parser.Next(from)
+}
+
+func (s *Schedule) Prev(from time.Time) time.Time {
+	return s.parser.Prev(from)
+}
+
+func (s *Schedule) Between(from, to time.Time) []time.Time {
+	return s.parser.Between(from, to)
+}
+
+func (s *Schedule) NextN(from time.Time, n int) []time.Time {
+	return s.parser.NextN(from, n)
+}
+
+func (s *Schedule) Describe() string {
+	return s.parser.Describe()
+}
+
+func (s *Schedule) DescribeIn(localeName string) (string, error) {
+	return s.parser.DescribeIn(localeName)
+}
+
+func (s *Schedule) Canonical() string {
+	return s.parser.Canonical()
+}
+
+func (s *Schedule) CanonicalFields(names bool) []string {
+	return s.parser.CanonicalFields(names)
+}
+
+func (s *Schedule) String() string {
+	return s.parser.String()
+}
+
+func (s *Schedule) Result(from time.Time, nextRuns int) Result {
+	return s.parser.Result(from, nextRuns)
+}
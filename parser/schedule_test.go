@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"cron_expression_parser/parser/consts"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestShouldExposeParsedFieldsThroughSchedule(t *testing.T) {
+	schedule, err := Parse("*/15 0 1,15 * 1-5 /usr/bin/find")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	if !reflect.DeepEqual(schedule.Minutes(), []int{0, 15, 30, 45}) {
+		t.Fatalf("Should return minutes, actual: %v", schedule.Minutes())
+	}
+
+	if !reflect.DeepEqual(schedule.DaysOfWeek(), []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("Should return days of week, actual: %v", schedule.DaysOfWeek())
+	}
+
+	if schedule.Command() != "/usr/bin/find" {
+		t.Fatalf("Should return command, actual: %v", schedule.Command())
+	}
+
+	if schedule.Expression() != "*/15 0 1,15 * 1-5" {
+		t.Fatalf("Should return expression as written, actual: %v", schedule.Expression())
+	}
+}
+
+func TestShouldReturnFieldsWithMetadata(t *testing.T) {
+	schedule, err := Parse("*/15 0 1,15 * 1-5 /usr/bin/find")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	fields := schedule.Fields()
+	if len(fields) != 5 {
+		t.Fatalf("Should return five fields, actual: %d", len(fields))
+	}
+
+	dayOfMonth := fields[2]
+	if dayOfMonth.GetName() != "day of month" || dayOfMonth.GetMinValue() != 1 || dayOfMonth.GetMaxValue() != 31 {
+		t.Fatalf("Should return day of month metadata, actual: %s %d-%d", dayOfMonth.GetName(), dayOfMonth.GetMinValue(), dayOfMonth.GetMaxValue())
+	}
+
+	if dayOfMonth.Raw != "1,15" || !reflect.DeepEqual(dayOfMonth.Values, []int{1, 15}) {
+		t.Fatalf("Should return day of month values, actual: %q %v", dayOfMonth.Raw, dayOfMonth.Values)
+	}
+}
+
+func TestShouldNotShareFieldMetadataWithParser(t *testing.T) {
+	schedule, err := Parse("*/15 0 1,15 * 1-5 /usr/bin/find")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	schedule.Fields()[2].Value.(*consts.DayOfMonth).Name = "changed"
+	if schedule.Fields()[2].GetName() != "day of month" {
+		t.Fatalf("Should return a copy of the field metadata")
+	}
+
+	_, err = Parse("0 0 32 * * cmd")
+	if err == nil || !strings.Contains(err.Error(), "day of month") {
+		t.Fatalf("Should keep the field names of the parser, actual: %v", err)
+	}
+}
+
+func TestShouldNotChangeScheduleAfterParsingAgain(t *testing.T) {
+	parser := NewParser()
+	err := parser.Parse("1 1 1 1 1 first")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+	schedule := parser.Schedule()
+
+	err = parser.Parse("2 2 2 2 2 second")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	schedule.Minutes()[0] = 42
+	if !reflect.DeepEqual(schedule.Minutes(), []int{1}) || schedule.Command() != "first" {
+		t.Fatalf("Should keep schedule read-only, actual: %v %s", schedule.Minutes(), schedule.Command())
+	}
+}
+
+func TestShouldParseTimeFieldsWithoutCommand(t *testing.T) {
+	schedule, err := ParseTimeFields("30  2 * * MON-FRI")
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+
+	if schedule.Command() != "" || schedule.String() != "30 2 * * 1-5" {
+		t.Fatalf("Should parse schedule without command, actual: %q", schedule.String())
+	}
+
+	_, err = ParseTimeFields("30 2 * * * cmd")
+	if err == nil {
+		t.Fatalf("Should reject input with a command")
+	}
+}
+
+func TestShouldTellEquivalentSchedules(t *testing.T) {
+	testScenarios := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"0,15,30,45 0 * * 1,2,3,4,5", "*/15 0 * * MON-FRI", true},
+		{"0 0 1-31/2 * 0-6/2", "0 0 */2 * 0-6/2", false},
+		{"0 0 1 * 0-6", "0 0 1 * *", false},
+		{"0 0 1 * *", "0 0 2 * *", false},
+		{"0 0 1-31 * 1", "0 0 * * *", true},
+		{"0 0 1,15 * 0-6", "0 0 * * *", true},
+		{"0 0 1-7 * 1", "0 0 1-7 * *", false},
+		{"0 0 31 2 *", "0 0 30 2 *", true},
+	}
+
+	for _, scenario := range testScenarios {
+		a, _ := ParseTimeFields(scenario.a)
+		b, _ := ParseTimeFields(scenario.b)
+		if a.Equivalent(b) != scenario.expected {
+			t.Errorf("Should tell whether %q and %q are equivalent, expected: %v", scenario.a, scenario.b, scenario.expected)
+		}
+	}
+
+	or, _ := ParseTimeFields("0 0 1-7 * 1")
+	if or.Equivalent(or.WithDayPolicy(DayPolicyAnd)) {
+		t.Errorf("Should tell schedules with other day policies apart")
+	}
+}
+
+func TestShouldParseConcurrently(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			schedule, err := Parse("*/15 0 1,15 * 1-5 /usr/bin/find")
+			if err != nil {
+				t.Errorf("Should not return error with proper input; %s", err)
+				return
+			}
+			if len(schedule.Fields()) != 5 {
+				t.Errorf("Should return five fields, actual: %d", len(schedule.Fields()))
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"cron_expression_parser/ical"
+	"cron_expression_parser/parser"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateTimeLayouts are the iCalendar forms of DTSTART, in UTC or floating.
+var dateTimeLayouts = []string{"20060102T150405Z", "20060102T150405"}
+
+func runRRule(c *cli, args []string) int {
+	flags := c.flagSet("rrule", "<rrule | ->", "Prints the cron expression equivalent to an RFC 5545 RRULE, or why there is none.\nA DTSTART line may be given before the RRULE, as in an .ics file.")
+	dtstartFlag := flags.String("dtstart", "", "start of the rule, for the fields it does not set, e.g. 2026-11-04T09:30")
+	names := flags.Bool("names", false, "write months and days of week as names (JAN-DEC, SUN-SAT)")
+	dayPolicy := dayPolicyFlag(flags)
+	args, code, ok := parseFlags(flags, args)
+	if !ok {
+		return code
+	}
+
+	if len(args) != 1 {
+		return usageError(flags, "Please provide one RRULE")
+	}
+
+	policy, err := parser.ParseDayPolicy(*dayPolicy)
+	if err != nil {
+		return usageError(flags, "%s", err)
+	}
+
+	var dtstart time.Time
+	if *dtstartFlag != "" {
+		dtstart, err = parseDTStart(*dtstartFlag)
+		if err != nil {
+			return usageError(flags, "%s", err)
+		}
+	}
+
+	input, err := c.readInput(args[0])
+	if err != nil {
+		return c.fail(err)
+	}
+
+	rrule := ""
+	for _, line := range strings.Split(input, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(strings.ToUpper(line), "DTSTART") {
+			_, value, _ := strings.Cut(line, ":")
+			dtstart, err = parseDTStart(value)
+			if err != nil {
+				return c.fail(err)
+			}
+			continue
+		}
+		if line != "" {
+			rrule = line
+		}
+	}
+
+	schedule, err := ical.ToCron(rrule, dtstart)
+	if err != nil {
+		return c.fail(err)
+	}
+
+	expression := strings.Join(schedule.CanonicalFields(*names), " ")
+	// Cron would run the expression when either day field matches, so it is
+	// only printed for those who run it with the AND policy.
+	if schedule.DayPolicy() == parser.DayPolicyAnd && policy != parser.DayPolicyAnd {
+		return c.fail(errors.New(fmt.Sprintf("The rule narrows down both day of month and day of week, %q is only equivalent with --day-policy and", expression)))
+	}
+	fmt.Fprintln(c.stdout, expression)
+	return exitOK
+}
+
+// parseDTStart parses DTSTART in the iCalendar form or one of fromLayouts.
+// The time is kept in its own offset, as the expression fires at its wall
+// clock time.
+func parseDTStart(value string) (time.Time, error) {
+	for _, layout := range append(dateTimeLayouts, fromLayouts...) {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Cannot parse DTSTART %q, should be like 20261104T093000Z or 2026-11-04T09:30", value)
+}
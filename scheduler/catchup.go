@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxMissedRuns is the most runs CatchUpAll starts for one job, as in
+// Kubernetes CronJobs. A job that missed more only runs for the latest fire
+// time and reports an error, set a StartingDeadline to catch up less.
+const MaxMissedRuns = 100
+
+// CatchUpPolicy says what to do with fire times missed while the scheduler
+// was not running, found from the last run kept in the store.
+type CatchUpPolicy int
+
+const (
+	// CatchUpNone drops missed runs.
+	CatchUpNone CatchUpPolicy = iota
+	// CatchUpOnce runs the job once for the latest missed fire time.
+	CatchUpOnce
+	// CatchUpAll runs the job for every missed fire time, oldest first and
+	// one after the other, up to MaxMissedRuns.
+	CatchUpAll
+)
+
+func (p CatchUpPolicy) String() string {
+	switch p {
+	case CatchUpOnce:
+		return "once"
+	case CatchUpAll:
+		return "all"
+	}
+	return "none"
+}
+
+// ParseCatchUpPolicy returns the policy named as by String.
+func ParseCatchUpPolicy(name string) (CatchUpPolicy, error) {
+	for _, policy := range []CatchUpPolicy{CatchUpNone, CatchUpOnce, CatchUpAll} {
+		if policy.String() == name {
+			return policy, nil
+		}
+	}
+	return CatchUpNone, errors.New(fmt.Sprintf("Unknown catch-up policy %q, should be one of: none, once, all", name))
+}
+
+// missedRuns returns the fire times of the entry between its last run and
+// now that are still within its starting deadline. With more than
+// MaxMissedRuns of them it returns only the latest one and an error. It is
+// called with s.mu held.
+func (s *Scheduler) missedRuns(e *entry, now time.Time) ([]time.Time, error) {
+	if s.store == nil || e.job.CatchUp == CatchUpNone {
+		return []time.Time{}, nil
+	}
+
+	lastRun, ok, err := s.store.LastRun(e.job.Name)
+	if err != nil || !ok {
+		return []time.Time{}, err
+	}
+
+	if e.job.StartingDeadline > 0 && lastRun.Before(now.Add(-e.job.StartingDeadline)) {
+		lastRun = now.Add(-e.job.StartingDeadline)
+	}
+
+	if e.job.CatchUp == CatchUpOnce {
+		latest := e.job.Schedule.Prev(now.Add(time.Nanosecond))
+		if latest.IsZero() || !latest.After(lastRun) {
+			return []time.Time{}, nil
+		}
+		return []time.Time{latest}, nil
+	}
+
+	missed := []time.Time{}
+	for t := e.job.Schedule.Next(lastRun); !t.IsZero() && !t.After(now); t = e.job.Schedule.Next(t) {
+		if len(missed) == MaxMissedRuns {
+			latest := e.job.Schedule.Prev(now.Add(time.Nanosecond))
+			return []time.Time{latest}, errors.New(fmt.Sprintf("Job %q missed more than %d runs, running only the latest, set a starting deadline to catch up less", e.job.Name, MaxMissedRuns))
+		}
+		missed = append(missed, t)
+	}
+	return missed, nil
+}
+
+// catchUp fires the oldest missed run of the entry and queues the others,
+// each of them starts once the run before it finished, see finish. It is
+// called with s.mu held and returns the events to emit once it is released.
+func (s *Scheduler) catchUp(e *entry, now time.Time) []Event {
+	missed, err := s.missedRuns(e, now)
+	events := []Event{}
+	if err != nil {
+		events = append(events, Event{Type: EventError, JobID: e.id, Name: e.job.Name, FireTime: now, Err: err})
+	}
+	if len(missed) == 0 {
+		return events
+	}
+
+	events = append(events, s.fire(e, missed[0])...)
+	for _, fireTime := range missed[1:] {
+		events = append(events, Event{Type: EventQueued, JobID: e.id, Name: e.job.Name, FireTime: fireTime})
+	}
+	e.missed = append(e.missed, missed[1:]...)
+	return events
+}
+
+// tooLate reports whether a run is past the starting deadline of its job,
+// e.g. because the process was suspended.
+func tooLate(job Job, fireTime, now time.Time) bool {
+	return job.StartingDeadline > 0 && now.Sub(fireTime) > job.StartingDeadline
+}
@@ -0,0 +1,202 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, time.October, 19, hour, minute, 0, 0, time.UTC)
+}
+
+func startWithMissedRuns(t *testing.T, job Job) (*fakeClock, *Scheduler, *MemoryStore, chan Event) {
+	store := NewMemoryStore()
+	store.SetLastRun("hourly", at(7, 0))
+
+	clock := newFakeClock(start)
+	events := make(chan Event, 20)
+	s := New(Config{Clock: clock, Store: store, OnEvent: func(event Event) { events <- event }})
+
+	job.Name = "hourly"
+	job.Schedule = mustParse(t, "0 * * * * cmd")
+	job.Run = func(ctx context.Context) {}
+	_, err := s.Add(job)
+	if err != nil {
+		t.Fatalf("Should add job; %s", err)
+	}
+	s.Start()
+	return clock, s, store, events
+}
+
+// startedRuns collects fire times of started runs until no event comes in.
+func startedRuns(events <-chan Event) []time.Time {
+	res := []time.Time{}
+	for {
+		select {
+		case event := <-events:
+			if event.Type == EventStarted {
+				res = append(res, event.FireTime)
+			}
+		case <-time.After(50 * time.Millisecond):
+			return res
+		}
+	}
+}
+
+func TestShouldCatchUpMissedRunsAsPolicySays(t *testing.T) {
+	testScenarios := []struct {
+		job      Job
+		expected []time.Time
+		comment  string
+	}{
+		{Job{}, []time.Time{}, "Should drop missed runs by default"},
+		{Job{CatchUp: CatchUpOnce}, []time.Time{at(10, 0)}, "Should run once for latest missed run"},
+		{Job{CatchUp: CatchUpAll}, []time.Time{at(8, 0), at(9, 0), at(10, 0)}, "Should run every missed run"},
+		{Job{CatchUp: CatchUpAll, StartingDeadline: 90 * time.Minute}, []time.Time{at(9, 0), at(10, 0)}, "Should ignore runs older than starting deadline"},
+		{Job{CatchUp: CatchUpOnce, StartingDeadline: 10 * time.Second}, []time.Time{}, "Should ignore runs older than starting deadline"},
+	}
+
+	for _, scenario := range testScenarios {
+		_, s, _, events := startWithMissedRuns(t, scenario.job)
+		runs := startedRuns(events)
+		s.Stop(context.Background())
+
+		if len(runs) != len(scenario.expected) {
+			t.Fatalf("%s, expected: %v, actual: %v", scenario.comment, scenario.expected, runs)
+		}
+		for i := range runs {
+			if !runs[i].Equal(scenario.expected[i]) {
+				t.Fatalf("%s, expected: %v, actual: %v", scenario.comment, scenario.expected, runs)
+			}
+		}
+	}
+}
+
+func TestShouldCapMissedRunsWithoutStartingDeadline(t *testing.T) {
+	store := NewMemoryStore()
+	store.SetLastRun("minutely", start.Add(-24*time.Hour))
+
+	events := make(chan Event, 20)
+	s := New(Config{Clock: newFakeClock(start), Store: store, OnEvent: func(event Event) { events <- event }})
+	_, err := s.Add(Job{Name: "minutely", Schedule: mustParse(t, "* * * * * cmd"), Run: func(ctx context.Context) {}, CatchUp: CatchUpAll})
+	if err != nil {
+		t.Fatalf("Should add job; %s", err)
+	}
+	s.Start()
+	defer s.Stop(context.Background())
+
+	event := waitForEvent(t, events, EventError)
+	if !strings.Contains(event.Err.Error(), "missed more than 100 runs") {
+		t.Fatalf("Should report too many missed runs, actual: %s", event.Err)
+	}
+	runs := startedRuns(events)
+	if len(runs) != 1 || !runs[0].Equal(at(10, 0)) {
+		t.Fatalf("Should run only for the latest missed fire time, actual: %v", runs)
+	}
+}
+
+func TestShouldRunMissedRunsOneAfterTheOther(t *testing.T) {
+	store := NewMemoryStore()
+	store.SetLastRun("hourly", at(7, 0))
+	s := New(Config{Clock: newFakeClock(start), Store: store})
+
+	var mu sync.Mutex
+	running, overlapped := 0, false
+	runs := make(chan time.Time, 10)
+	_, err := s.Add(Job{Name: "hourly", Schedule: mustParse(t, "0 * * * * cmd"), CatchUp: CatchUpAll, Run: func(ctx context.Context) {
+		mu.Lock()
+		running++
+		overlapped = overlapped || running > 1
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+		fireTime, _ := FireTime(ctx)
+		runs <- fireTime
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}})
+	if err != nil {
+		t.Fatalf("Should add job; %s", err)
+	}
+	s.Start()
+	defer s.Stop(context.Background())
+
+	for _, expected := range []time.Time{at(8, 0), at(9, 0), at(10, 0)} {
+		fireTime := waitFor(t, runs, "Should run every missed run")
+		if !fireTime.Equal(expected) {
+			t.Fatalf("Should run missed runs oldest first, expected: %v, actual: %v", expected, fireTime)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if overlapped {
+		t.Fatalf("Should not start a missed run before the previous one finished")
+	}
+}
+
+func TestShouldKeepLastRunInStore(t *testing.T) {
+	clock, s, store, events := startWithMissedRuns(t, Job{CatchUp: CatchUpOnce})
+	defer s.Stop(context.Background())
+	startedRuns(events)
+
+	clock.Advance(time.Hour)
+	waitForEvent(t, events, EventStarted)
+	waitForEvent(t, events, EventFinished)
+
+	lastRun, ok, err := store.LastRun("hourly")
+	if err != nil || !ok || !lastRun.Equal(at(11, 0)) {
+		t.Fatalf("Should keep last run in store, actual: %v", lastRun)
+	}
+}
+
+func TestShouldSkipRunsPastStartingDeadline(t *testing.T) {
+	clock := newFakeClock(start)
+	events := make(chan Event, 20)
+	s := New(Config{Clock: clock, OnEvent: func(event Event) { events <- event }})
+
+	_, err := s.Add(Job{Schedule: mustParse(t, "* * * * * cmd"), Run: func(ctx context.Context) {}, StartingDeadline: 10 * time.Second})
+	if err != nil {
+		t.Fatalf("Should add job; %s", err)
+	}
+	s.Start()
+	defer s.Stop(context.Background())
+
+	clock.Advance(2 * time.Minute)
+	waitForEvent(t, events, EventSkipped)
+}
+
+func TestShouldNotWriteJobsWithoutCatchUpToStore(t *testing.T) {
+	store := NewMemoryStore()
+	events := make(chan Event, 20)
+	clock := newFakeClock(start)
+	s := New(Config{Clock: clock, Store: store, OnEvent: func(event Event) { events <- event }})
+	_, err := s.Add(Job{Name: "plain", Schedule: mustParse(t, "* * * * * cmd"), Run: func(ctx context.Context) {}})
+	if err != nil {
+		t.Fatalf("Should add job; %s", err)
+	}
+	s.Start()
+	defer s.Stop(context.Background())
+
+	clock.Advance(30 * time.Second)
+	waitForEvent(t, events, EventStarted)
+	waitForEvent(t, events, EventFinished)
+
+	_, ok, _ := store.LastRun("plain")
+	if ok {
+		t.Fatalf("Should not keep last run of job without catch-up")
+	}
+}
+
+func TestShouldReturnErrorForCatchUpWithoutName(t *testing.T) {
+	s := New(Config{Store: NewMemoryStore()})
+	_, err := s.Add(Job{Schedule: mustParse(t, "* * * * * cmd"), Run: func(ctx context.Context) {}, CatchUp: CatchUpAll})
+	if err == nil {
+		t.Fatalf("Should return error for catch-up without job name")
+	}
+}
@@ -0,0 +1,165 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// OverlapPolicy says what happens when a job is still running at its next
+// fire time. It mirrors concurrencyPolicy of Kubernetes CronJobs.
+type OverlapPolicy int
+
+const (
+	// Allow starts the new run next to the running one.
+	Allow OverlapPolicy = iota
+	// Forbid skips the new run.
+	Forbid
+	// Queue runs the new run once the running one finishes, keeping at
+	// most Job.MaxQueued runs waiting. Runs above that are skipped.
+	Queue
+	// Replace cancels the context of the running run and starts the new one.
+	Replace
+)
+
+func (p OverlapPolicy) String() string {
+	switch p {
+	case Forbid:
+		return "forbid"
+	case Queue:
+		return "queue"
+	case Replace:
+		return "replace"
+	}
+	return "allow"
+}
+
+type EventType int
+
+const (
+	EventStarted EventType = iota
+	EventFinished
+	EventSkipped
+	EventQueued
+	EventReplaced
+	EventError
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventFinished:
+		return "finished"
+	case EventSkipped:
+		return "skipped"
+	case EventQueued:
+		return "queued"
+	case EventReplaced:
+		return "replaced"
+	case EventError:
+		return "error"
+	}
+	return "started"
+}
+
+// Event reports what the scheduler did with one fire time of a job.
+type Event struct {
+	Type     EventType
+	JobID    JobID
+	Name     string
+	FireTime time.Time
+	Err      error
+}
+
+// fire applies the overlap policy of the entry to a run at fireTime. It is
+// called with s.mu held and returns the events to emit once it is released.
+func (s *Scheduler) fire(e *entry, fireTime time.Time) []Event {
+	event := func(eventType EventType) Event {
+		return Event{Type: eventType, JobID: e.id, Name: e.job.Name, FireTime: fireTime}
+	}
+
+	if e.active == 0 {
+		return []Event{s.launch(e, fireTime)}
+	}
+
+	switch e.job.Overlap {
+	case Forbid:
+		return []Event{event(EventSkipped)}
+	case Queue:
+		if len(e.queued) >= e.job.MaxQueued {
+			return []Event{event(EventSkipped)}
+		}
+		e.queued = append(e.queued, fireTime)
+		return []Event{event(EventQueued)}
+	case Replace:
+		e.cancelRun()
+		return []Event{event(EventReplaced), s.launch(e, fireTime)}
+	}
+	return []Event{s.launch(e, fireTime)}
+}
+
+// launch starts a run of the entry in its own goroutine. It is called with
+// s.mu held.
+func (s *Scheduler) launch(e *entry, fireTime time.Time) Event {
+	ctx, cancel := context.WithCancel(context.WithValue(s.ctx, fireTimeKey{}, fireTime))
+	e.cancelRun = cancel
+	e.active++
+
+	// The job is copied as Update may replace it while the run is going.
+	job := e.job
+	s.working.Add(1)
+	go func() {
+		defer s.working.Done()
+		// Only jobs that catch up are known to the store.
+		if s.store != nil && job.CatchUp != CatchUpNone {
+			err := s.store.SetLastRun(job.Name, fireTime)
+			if err != nil {
+				s.emit([]Event{{Type: EventError, JobID: e.id, Name: job.Name, FireTime: fireTime, Err: err}})
+			}
+		}
+		job.Run(ctx)
+		cancel()
+		s.finish(e, fireTime)
+	}()
+	return Event{Type: EventStarted, JobID: e.id, Name: job.Name, FireTime: fireTime}
+}
+
+// finish records the end of a run and starts the oldest missed or else the
+// oldest queued run with its own fire time, unless the job was removed or the
+// scheduler stopped in the meantime. Missed runs past the starting deadline
+// are skipped.
+func (s *Scheduler) finish(e *entry, fireTime time.Time) {
+	s.mu.Lock()
+	events := []Event{{Type: EventFinished, JobID: e.id, Name: e.job.Name, FireTime: fireTime}}
+	e.active--
+	_, registered := s.entries[e.id]
+	for len(e.missed) > 0 && e.active == 0 {
+		missed := e.missed[0]
+		e.missed = e.missed[1:]
+		if !registered || !s.running {
+			continue
+		}
+		if tooLate(e.job, missed, s.clock.Now()) {
+			events = append(events, Event{Type: EventSkipped, JobID: e.id, Name: e.job.Name, FireTime: missed})
+			continue
+		}
+		events = append(events, s.launch(e, missed))
+	}
+	if len(e.queued) > 0 && e.active == 0 {
+		queued := e.queued[0]
+		e.queued = e.queued[1:]
+		if registered && s.running {
+			events = append(events, s.launch(e, queued))
+		}
+	}
+	s.mu.Unlock()
+
+	s.emit(events)
+}
+
+func (s *Scheduler) emit(events []Event) {
+	if s.onEvent == nil {
+		return
+	}
+	for _, event := range events {
+		s.onEvent(event)
+	}
+}
@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// blockingJob runs until released or cancelled and reports how it ended.
+type blockingJob struct {
+	release   chan struct{}
+	cancelled chan time.Time
+}
+
+func newBlockingJob() *blockingJob {
+	return &blockingJob{release: make(chan struct{}, 10), cancelled: make(chan time.Time, 10)}
+}
+
+func (j *blockingJob) Run(ctx context.Context) {
+	select {
+	case <-j.release:
+	case <-ctx.Done():
+		j.cancelled <- time.Now()
+	}
+}
+
+func waitForEvent(t *testing.T, events <-chan Event, expected EventType) Event {
+	select {
+	case event := <-events:
+		if event.Type != expected {
+			t.Fatalf("Should emit %s event, actual: %s", expected, event.Type)
+		}
+		return event
+	case <-time.After(time.Second):
+		t.Fatalf("Should emit %s event", expected)
+	}
+	return Event{}
+}
+
+func startOverlapping(t *testing.T, job Job) (*fakeClock, *Scheduler, chan Event) {
+	clock := newFakeClock(start)
+	events := make(chan Event, 20)
+	s := New(Config{Clock: clock, OnEvent: func(event Event) { events <- event }})
+
+	job.Schedule = mustParse(t, "* * * * * cmd")
+	_, err := s.Add(job)
+	if err != nil {
+		t.Fatalf("Should add job; %s", err)
+	}
+	s.Start()
+
+	clock.Advance(30 * time.Second)
+	waitForEvent(t, events, EventStarted)
+	clock.Advance(time.Minute)
+	return clock, s, events
+}
+
+func TestShouldAllowOverlappingRunsByDefault(t *testing.T) {
+	job := newBlockingJob()
+	_, s, events := startOverlapping(t, Job{Run: job.Run})
+	defer s.Stop(context.Background())
+
+	waitForEvent(t, events, EventStarted)
+	job.release <- struct{}{}
+	job.release <- struct{}{}
+	waitForEvent(t, events, EventFinished)
+	waitForEvent(t, events, EventFinished)
+}
+
+func TestShouldSkipRunWithForbidPolicy(t *testing.T) {
+	job := newBlockingJob()
+	_, s, events := startOverlapping(t, Job{Name: "backup", Run: job.Run, Overlap: Forbid})
+	defer s.Stop(context.Background())
+
+	event := waitForEvent(t, events, EventSkipped)
+	if event.Name != "backup" || !event.FireTime.Equal(time.Date(2026, time.October, 19, 10, 2, 0, 0, time.UTC)) {
+		t.Fatalf("Should report skipped fire time, actual: %+v", event)
+	}
+
+	job.release <- struct{}{}
+	waitForEvent(t, events, EventFinished)
+}
+
+func TestShouldQueueAtMostMaxQueuedRuns(t *testing.T) {
+	job := newBlockingJob()
+	clock, s, events := startOverlapping(t, Job{Run: job.Run, Overlap: Queue, MaxQueued: 1})
+	defer s.Stop(context.Background())
+
+	waitForEvent(t, events, EventQueued)
+	clock.Advance(time.Minute)
+	waitForEvent(t, events, EventSkipped)
+
+	job.release <- struct{}{}
+	waitForEvent(t, events, EventFinished)
+	waitForEvent(t, events, EventStarted)
+
+	job.release <- struct{}{}
+	waitForEvent(t, events, EventFinished)
+}
+
+func TestShouldStartQueuedRunsWithTheirOwnFireTimes(t *testing.T) {
+	job := newBlockingJob()
+	clock, s, events := startOverlapping(t, Job{Run: job.Run, Overlap: Queue, MaxQueued: 2})
+	defer s.Stop(context.Background())
+
+	first := waitForEvent(t, events, EventQueued)
+	clock.Advance(time.Minute)
+	second := waitForEvent(t, events, EventQueued)
+
+	for _, queued := range []Event{first, second} {
+		job.release <- struct{}{}
+		waitForEvent(t, events, EventFinished)
+		started := waitForEvent(t, events, EventStarted)
+		if !started.FireTime.Equal(queued.FireTime) {
+			t.Fatalf("Should start queued run with its fire time %v, actual: %v", queued.FireTime, started.FireTime)
+		}
+	}
+
+	job.release <- struct{}{}
+	waitForEvent(t, events, EventFinished)
+}
+
+func TestShouldCancelRunningRunWithReplacePolicy(t *testing.T) {
+	job := newBlockingJob()
+	_, s, events := startOverlapping(t, Job{Run: job.Run, Overlap: Replace})
+	defer s.Stop(context.Background())
+
+	waitForEvent(t, events, EventReplaced)
+	waitForEvent(t, events, EventStarted)
+
+	select {
+	case <-job.cancelled:
+	case <-time.After(time.Second):
+		t.Fatalf("Should cancel the replaced run")
+	}
+	waitForEvent(t, events, EventFinished)
+
+	job.release <- struct{}{}
+	waitForEvent(t, events, EventFinished)
+}
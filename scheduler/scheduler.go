@@ -0,0 +1,349 @@
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"cron_expression_parser/parser"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+type JobID int64
+
+var ErrJobNotFound = errors.New("Job not found")
+
+// Job is a function run at the fire times of a parsed schedule.
+type Job struct {
+	Name     string
+	Schedule *parser.Schedule
+	Run      func(ctx context.Context)
+
+	// Overlap says what to do when the job is still running at its next
+	// fire time, runs are allowed to overlap by default.
+	Overlap   OverlapPolicy
+	MaxQueued int
+
+	// CatchUp says what to do with runs missed while the scheduler was not
+	// running. It needs a Name and Config.Store.
+	CatchUp CatchUpPolicy
+	// StartingDeadline drops runs that could not start within it of their
+	// fire time, both missed and late ones. Zero means no deadline.
+	StartingDeadline time.Duration
+}
+
+type fireTimeKey struct{}
+
+// FireTime returns the fire time of the run a job was started for, from the
+// context passed to Job.Run.
+func FireTime(ctx context.Context) (time.Time, bool) {
+	fireTime, ok := ctx.Value(fireTimeKey{}).(time.Time)
+	return fireTime, ok
+}
+
+// Entry describes a registered job.
+type Entry struct {
+	ID   JobID
+	Name string
+	Next time.Time
+}
+
+type Config struct {
+	// Clock defaults to RealClock.
+	Clock Clock
+	// OnEvent is called for every run that is started, finished, skipped,
+	// queued or replaced. It must not call back into the scheduler.
+	OnEvent func(Event)
+	// Store keeps the last run of jobs that catch up missed runs, other
+	// jobs are not written to it.
+	Store LastRunStore
+	// CancelGrace is how long Stop still waits for runs after cancelling
+	// their context, so they can clean up, e.g. kill their processes. Zero
+	// means Stop returns right after cancelling.
+	CancelGrace time.Duration
+}
+
+type entry struct {
+	id    JobID
+	job   Job
+	next  time.Time
+	index int
+
+	active int
+	queued []time.Time
+	// missed are the runs caught up after the one in progress, they go
+	// before the queued ones.
+	missed    []time.Time
+	cancelRun context.CancelFunc
+}
+
+// Scheduler runs jobs at the fire times of their schedules. All jobs share
+// one timer set to the earliest next fire time.
+type Scheduler struct {
+	clock   Clock
+	onEvent func(Event)
+	store   LastRunStore
+	grace   time.Duration
+
+	mu      sync.Mutex
+	entries map[JobID]*entry
+	queue   entryHeap
+	lastID  JobID
+	running bool
+	stopped bool
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	working sync.WaitGroup
+}
+
+func New(config Config) *Scheduler {
+	clock := config.Clock
+	if clock == nil {
+		clock = RealClock()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		clock:   clock,
+		onEvent: config.OnEvent,
+		store:   config.Store,
+		grace:   config.CancelGrace,
+		entries: map[JobID]*entry{},
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Add registers a job. It can be called before or after Start.
+func (s *Scheduler) Add(job Job) (JobID, error) {
+	if err := validate(job); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	events := []Event{}
+	s.lastID++
+	e := &entry{id: s.lastID, job: job, index: -1}
+	s.entries[e.id] = e
+	if s.running {
+		now := s.clock.Now()
+		events = s.catchUp(e, now)
+		s.schedule(e, now)
+	}
+	s.mu.Unlock()
+
+	s.emit(events)
+	return e.id, nil
+}
+
+// Update replaces a registered job, keeping its runs in progress and
+// queued. The next fire time is taken from the new schedule.
+func (s *Scheduler) Update(id JobID, job Job) error {
+	if err := validate(job); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[id]
+	if !ok {
+		return ErrJobNotFound
+	}
+	e.job = job
+	if s.running {
+		if e.index >= 0 {
+			heap.Remove(&s.queue, e.index)
+		}
+		s.schedule(e, s.clock.Now())
+	}
+	return nil
+}
+
+func validate(job Job) error {
+	if job.Schedule == nil || job.Run == nil {
+		return errors.New("Job needs a schedule and a function to run")
+	}
+
+	if job.CatchUp != CatchUpNone && job.Name == "" {
+		return errors.New("Job needs a name to catch up missed runs")
+	}
+	return nil
+}
+
+// Remove unregisters a job. Runs already in progress are not interrupted.
+func (s *Scheduler) Remove(id JobID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[id]
+	if !ok {
+		return
+	}
+	delete(s.entries, id)
+	if e.index >= 0 {
+		heap.Remove(&s.queue, e.index)
+		s.notify()
+	}
+}
+
+// Entries returns the registered jobs ordered by their next fire time.
+func (s *Scheduler) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := []Entry{}
+	for _, e := range s.entries {
+		res = append(res, Entry{ID: e.id, Name: e.job.Name, Next: e.next})
+	}
+	sortEntries(res)
+	return res
+}
+
+// Start catches up missed runs and begins running jobs in a background
+// goroutine. A stopped scheduler cannot be started again.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	if s.running || s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+
+	events := []Event{}
+	now := s.clock.Now()
+	for _, e := range s.sortedEntries() {
+		events = append(events, s.catchUp(e, now)...)
+		s.schedule(e, now)
+	}
+	go s.loop()
+	s.mu.Unlock()
+
+	s.emit(events)
+}
+
+// Stop stops firing new runs and waits for the ones in progress. When ctx
+// is done first, the context of running jobs is cancelled, they are waited
+// for up to Config.CancelGrace more and ctx.Err() is returned.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.stopped = true
+	s.mu.Unlock()
+
+	close(s.stop)
+	<-s.done
+
+	drained := make(chan struct{})
+	go func() {
+		s.working.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		grace := time.NewTimer(s.grace)
+		defer grace.Stop()
+		select {
+		case <-drained:
+		case <-grace.C:
+		}
+		return ctx.Err()
+	}
+}
+
+// sortedEntries returns the entries in the order they were added. It is
+// called with s.mu held.
+func (s *Scheduler) sortedEntries() []*entry {
+	res := []*entry{}
+	for _, e := range s.entries {
+		res = append(res, e)
+	}
+	slices.SortFunc(res, func(a, b *entry) int { return int(a.id - b.id) })
+	return res
+}
+
+func (s *Scheduler) loop() {
+	defer close(s.done)
+
+	for {
+		var timer Timer
+		var fire <-chan time.Time
+
+		s.mu.Lock()
+		if len(s.queue) > 0 {
+			timer = s.clock.NewTimer(s.queue[0].next.Sub(s.clock.Now()))
+			fire = timer.C()
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-fire:
+			s.runDue()
+		case <-s.wake:
+		case <-s.stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+func (s *Scheduler) runDue() {
+	s.mu.Lock()
+	events := []Event{}
+	now := s.clock.Now()
+	for len(s.queue) > 0 && !s.queue[0].next.After(now) {
+		e := heap.Pop(&s.queue).(*entry)
+		if tooLate(e.job, e.next, now) {
+			events = append(events, Event{Type: EventSkipped, JobID: e.id, Name: e.job.Name, FireTime: e.next})
+		} else {
+			events = append(events, s.fire(e, e.next)...)
+		}
+		s.schedule(e, now)
+	}
+	s.mu.Unlock()
+
+	s.emit(events)
+}
+
+// schedule puts the entry back in the queue at its next fire time after now.
+// Schedules that never fire again are left out of the queue.
+func (s *Scheduler) schedule(e *entry, now time.Time) {
+	e.next = e.job.Schedule.Next(now)
+	if e.next.IsZero() {
+		return
+	}
+	heap.Push(&s.queue, e)
+	s.notify()
+}
+
+// notify wakes up the loop, so it resets its timer to the new earliest entry.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,324 @@
+package scheduler
+
+import (
+	"context"
+	"cron_expression_parser/parser"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock   *fakeClock
+	at      time.Time
+	c       chan time.Time
+	stopped bool
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, t)
+	c.fire()
+	return t
+}
+
+// Advance moves the clock forward and fires the timers that are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	c.fire()
+}
+
+func (c *fakeClock) fire() {
+	pending := []*fakeTimer{}
+	for _, t := range c.timers {
+		if t.stopped {
+			continue
+		}
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.stopped = true
+		t.c <- c.now
+	}
+	c.timers = pending
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	wasActive := !t.stopped
+	t.stopped = true
+	return wasActive
+}
+
+func mustParse(t *testing.T, input string) *parser.Schedule {
+	schedule, err := parser.Parse(input)
+	if err != nil {
+		t.Fatalf("Should not return error with proper input; %s", err)
+	}
+	return schedule
+}
+
+func waitFor(t *testing.T, c <-chan time.Time, comment string) time.Time {
+	select {
+	case at := <-c:
+		return at
+	case <-time.After(time.Second):
+		t.Fatalf("%s", comment)
+	}
+	return time.Time{}
+}
+
+func expectNothing(t *testing.T, c <-chan time.Time, comment string) {
+	select {
+	case <-c:
+		t.Fatalf("%s", comment)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+// recordingJob reports the clock time of each of its runs.
+func recordingJob(clock Clock) (func(ctx context.Context), chan time.Time) {
+	runs := make(chan time.Time, 10)
+	return func(ctx context.Context) { runs <- clock.Now() }, runs
+}
+
+var start = time.Date(2026, time.October, 19, 10, 0, 30, 0, time.UTC)
+
+func TestShouldRunJobAtFireTimes(t *testing.T) {
+	clock := newFakeClock(start)
+	s := New(Config{Clock: clock})
+	run, runs := recordingJob(clock)
+
+	_, err := s.Add(Job{Name: "every minute", Schedule: mustParse(t, "* * * * * cmd"), Run: run})
+	if err != nil {
+		t.Fatalf("Should add job; %s", err)
+	}
+	s.Start()
+	defer s.Stop(context.Background())
+
+	expectNothing(t, runs, "Should not run job before its fire time")
+
+	clock.Advance(30 * time.Second)
+	at := waitFor(t, runs, "Should run job at its fire time")
+	if !at.Equal(time.Date(2026, time.October, 19, 10, 1, 0, 0, time.UTC)) {
+		t.Fatalf("Should run job at 10:01, actual: %v", at)
+	}
+
+	clock.Advance(time.Minute)
+	waitFor(t, runs, "Should run job at its next fire time")
+}
+
+func TestShouldPassFireTimeToJob(t *testing.T) {
+	clock := newFakeClock(start)
+	s := New(Config{Clock: clock})
+	fireTimes := make(chan time.Time, 1)
+	_, err := s.Add(Job{Schedule: mustParse(t, "* * * * * cmd"), Run: func(ctx context.Context) {
+		fireTime, _ := FireTime(ctx)
+		fireTimes <- fireTime
+	}})
+	if err != nil {
+		t.Fatalf("Should add job; %s", err)
+	}
+	s.Start()
+	defer s.Stop(context.Background())
+
+	clock.Advance(45 * time.Second)
+	at := waitFor(t, fireTimes, "Should run job")
+	if !at.Equal(time.Date(2026, time.October, 19, 10, 1, 0, 0, time.UTC)) {
+		t.Fatalf("Should pass the fire time 10:01 to the job, actual: %v", at)
+	}
+
+	if _, ok := FireTime(context.Background()); ok {
+		t.Fatalf("Should not find fire time outside of a run")
+	}
+}
+
+func TestShouldAddAndRemoveJobsAtRuntime(t *testing.T) {
+	clock := newFakeClock(start)
+	s := New(Config{Clock: clock})
+	s.Start()
+	defer s.Stop(context.Background())
+
+	run, runs := recordingJob(clock)
+	id, err := s.Add(Job{Schedule: mustParse(t, "*/5 * * * * cmd"), Run: run})
+	if err != nil {
+		t.Fatalf("Should add job; %s", err)
+	}
+
+	entries := s.Entries()
+	if len(entries) != 1 || !entries[0].Next.Equal(time.Date(2026, time.October, 19, 10, 5, 0, 0, time.UTC)) {
+		t.Fatalf("Should schedule added job, actual: %v", entries)
+	}
+
+	clock.Advance(5 * time.Minute)
+	waitFor(t, runs, "Should run job added at runtime")
+
+	s.Remove(id)
+	clock.Advance(5 * time.Minute)
+	expectNothing(t, runs, "Should not run removed job")
+
+	if len(s.Entries()) != 0 {
+		t.Fatalf("Should not list removed job")
+	}
+}
+
+func TestShouldRescheduleUpdatedJob(t *testing.T) {
+	clock := newFakeClock(start)
+	events := make(chan Event, 20)
+	s := New(Config{Clock: clock, OnEvent: func(event Event) { events <- event }})
+	s.Start()
+	defer s.Stop(context.Background())
+
+	job := newBlockingJob()
+	id, _ := s.Add(Job{Name: "old", Schedule: mustParse(t, "* * * * * cmd"), Run: job.Run, Overlap: Forbid})
+	clock.Advance(30 * time.Second)
+	waitForEvent(t, events, EventStarted)
+
+	err := s.Update(id, Job{Name: "new", Schedule: mustParse(t, "*/5 * * * * cmd"), Run: job.Run, Overlap: Forbid})
+	if err != nil {
+		t.Fatalf("Should update job; %s", err)
+	}
+
+	entries := s.Entries()
+	if len(entries) != 1 || entries[0].Name != "new" || !entries[0].Next.Equal(time.Date(2026, time.October, 19, 10, 5, 0, 0, time.UTC)) {
+		t.Fatalf("Should use the new schedule, actual: %v", entries)
+	}
+
+	clock.Advance(4 * time.Minute)
+	event := waitForEvent(t, events, EventSkipped)
+	if event.Name != "new" {
+		t.Fatalf("Should keep the run in progress of the updated job, actual: %+v", event)
+	}
+
+	job.release <- struct{}{}
+	waitForEvent(t, events, EventFinished)
+
+	if s.Update(JobID(42), Job{Schedule: mustParse(t, "* * * * * cmd"), Run: job.Run}) != ErrJobNotFound {
+		t.Fatalf("Should return ErrJobNotFound for unknown job")
+	}
+}
+
+func TestShouldWaitForRunningJobsOnStop(t *testing.T) {
+	clock := newFakeClock(start)
+	s := New(Config{Clock: clock})
+
+	started := make(chan time.Time, 1)
+	release := make(chan struct{})
+	finished := false
+	_, err := s.Add(Job{Schedule: mustParse(t, "* * * * * cmd"), Run: func(ctx context.Context) {
+		started <- clock.Now()
+		<-release
+		finished = true
+	}})
+	if err != nil {
+		t.Fatalf("Should add job; %s", err)
+	}
+	s.Start()
+
+	clock.Advance(30 * time.Second)
+	waitFor(t, started, "Should run job")
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+
+	err = s.Stop(context.Background())
+	if err != nil || !finished {
+		t.Fatalf("Should drain running job on stop; %v", err)
+	}
+}
+
+func TestShouldCancelRunningJobsWhenStopDeadlinePasses(t *testing.T) {
+	clock := newFakeClock(start)
+	s := New(Config{Clock: clock})
+
+	started := make(chan time.Time, 1)
+	cancelled := make(chan time.Time, 1)
+	_, err := s.Add(Job{Schedule: mustParse(t, "* * * * * cmd"), Run: func(ctx context.Context) {
+		started <- clock.Now()
+		<-ctx.Done()
+		cancelled <- clock.Now()
+	}})
+	if err != nil {
+		t.Fatalf("Should add job; %s", err)
+	}
+	s.Start()
+
+	clock.Advance(30 * time.Second)
+	waitFor(t, started, "Should run job")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = s.Stop(ctx)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Should return deadline error, actual: %v", err)
+	}
+	waitFor(t, cancelled, "Should cancel context of running job")
+}
+
+func TestShouldWaitForCancelledJobsWithinGrace(t *testing.T) {
+	clock := newFakeClock(start)
+	s := New(Config{Clock: clock, CancelGrace: time.Second})
+
+	started := make(chan time.Time, 1)
+	var cleanedUp atomic.Bool
+	_, err := s.Add(Job{Schedule: mustParse(t, "* * * * * cmd"), Run: func(ctx context.Context) {
+		started <- clock.Now()
+		<-ctx.Done()
+		time.Sleep(20 * time.Millisecond)
+		cleanedUp.Store(true)
+	}})
+	if err != nil {
+		t.Fatalf("Should add job; %s", err)
+	}
+	s.Start()
+
+	clock.Advance(30 * time.Second)
+	waitFor(t, started, "Should run job")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = s.Stop(ctx)
+	if err != context.DeadlineExceeded || !cleanedUp.Load() {
+		t.Fatalf("Should wait for cancelled job to clean up, actual: %v %v", err, cleanedUp.Load())
+	}
+}
+
+func TestShouldReturnErrorForIncompleteJob(t *testing.T) {
+	s := New(Config{})
+	_, err := s.Add(Job{Name: "no schedule", Run: func(ctx context.Context) {}})
+	if err == nil {
+		t.Fatalf("Should return error for job without schedule")
+	}
+}
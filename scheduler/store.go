@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// LastRunStore keeps the fire time of the last run of every job, so missed
+// runs can be found after the scheduler restarts. Jobs are identified by
+// their names.
+type LastRunStore interface {
+	LastRun(name string) (time.Time, bool, error)
+	SetLastRun(name string, fireTime time.Time) error
+}
+
+// MemoryStore is a LastRunStore that does not survive restarts, meant for
+// tests and for jobs that do not need catch-up.
+type MemoryStore struct {
+	mu       sync.Mutex
+	lastRuns map[string]time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{lastRuns: map[string]time.Time{}}
+}
+
+func (m *MemoryStore) LastRun(name string) (time.Time, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lastRun, ok := m.lastRuns[name]
+	return lastRun, ok, nil
+}
+
+func (m *MemoryStore) SetLastRun(name string, fireTime time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if fireTime.After(m.lastRuns[name]) {
+		m.lastRuns[name] = fireTime
+	}
+	return nil
+}
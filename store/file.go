@@ -0,0 +1,275 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultHistoryLimit is the number of runs a store keeps per job.
+const DefaultHistoryLimit = 100
+
+const fileVersion = 1
+
+// FileStore keeps everything in one JSON file. Every change rewrites the
+// file to a temporary one and renames it over the old one, so a crash
+// leaves either the old or the new state, never a torn file.
+type FileStore struct {
+	path         string
+	historyLimit int
+
+	mu    sync.Mutex
+	state fileState
+}
+
+type fileState struct {
+	Version int                 `json:"version"`
+	Jobs    map[string]*fileJob `json:"jobs"`
+}
+
+type fileJob struct {
+	Expression string    `json:"expression"`
+	LastRun    time.Time `json:"lastRun"`
+	NextRun    time.Time `json:"nextRun"`
+	History    []fileRun `json:"history"`
+}
+
+type fileRun struct {
+	FireTime   time.Time `json:"fireTime"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	ExitCode   int       `json:"exitCode"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// OpenFile opens the store kept in path, creating it on the first write.
+func OpenFile(path string) (*FileStore, error) {
+	s := &FileStore{
+		path:         path,
+		historyLimit: DefaultHistoryLimit,
+		state:        fileState{Version: fileVersion, Jobs: map[string]*fileJob{}},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &s.state)
+	if err != nil {
+		return nil, err
+	}
+	if s.state.Jobs == nil {
+		s.state.Jobs = map[string]*fileJob{}
+	}
+	return s, nil
+}
+
+// SetHistoryLimit changes how many runs are kept per job.
+func (s *FileStore) SetHistoryLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.historyLimit = limit
+}
+
+func (s *FileStore) SaveJob(definition JobDefinition) error {
+	_, err := LoadJob(definition, time.Time{}, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.state.Jobs[definition.Name]
+	if !ok {
+		job = &fileJob{History: []fileRun{}}
+		s.state.Jobs[definition.Name] = job
+	}
+	job.Expression = definition.Expression
+	return s.write()
+}
+
+func (s *FileStore) DeleteJob(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.state.Jobs[name]; !ok {
+		return ErrJobNotFound
+	}
+	delete(s.state.Jobs, name)
+	return s.write()
+}
+
+func (s *FileStore) Job(name string) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.state.Jobs[name]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	return LoadJob(JobDefinition{Name: name, Expression: job.Expression}, job.LastRun, job.NextRun)
+}
+
+// Jobs returns all jobs ordered by name.
+func (s *FileStore) Jobs() ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := []string{}
+	for name := range s.state.Jobs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	res := []Job{}
+	for _, name := range names {
+		job := s.state.Jobs[name]
+		loaded, err := LoadJob(JobDefinition{Name: name, Expression: job.Expression}, job.LastRun, job.NextRun)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, loaded)
+	}
+	return res, nil
+}
+
+func (s *FileStore) LastRun(name string) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.state.Jobs[name]
+	if !ok || job.LastRun.IsZero() {
+		return time.Time{}, false, nil
+	}
+	return job.LastRun, true, nil
+}
+
+// SetLastRun keeps the latest of the stored and the given fire time, since
+// overlapping runs may report out of order.
+func (s *FileStore) SetLastRun(name string, fireTime time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.state.Jobs[name]
+	if !ok {
+		return ErrJobNotFound
+	}
+	if !fireTime.After(job.LastRun) {
+		return nil
+	}
+	job.LastRun = fireTime
+	return s.write()
+}
+
+func (s *FileStore) SetNextRun(name string, nextRun time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.state.Jobs[name]
+	if !ok {
+		return ErrJobNotFound
+	}
+	job.NextRun = nextRun
+	return s.write()
+}
+
+func (s *FileStore) AddRun(run Run) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.state.Jobs[run.JobName]
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	job.History = append(job.History, fileRun{
+		FireTime:   run.FireTime,
+		StartedAt:  run.StartedAt,
+		FinishedAt: run.FinishedAt,
+		ExitCode:   run.ExitCode,
+		Error:      run.Error,
+	})
+	if len(job.History) > s.historyLimit {
+		job.History = job.History[len(job.History)-s.historyLimit:]
+	}
+	return s.write()
+}
+
+func (s *FileStore) History(name string, limit int) ([]Run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.state.Jobs[name]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+
+	res := []Run{}
+	for i := len(job.History) - 1; i >= 0 && len(res) < limit; i-- {
+		run := job.History[i]
+		res = append(res, Run{
+			JobName:    name,
+			FireTime:   run.FireTime,
+			StartedAt:  run.StartedAt,
+			FinishedAt: run.FinishedAt,
+			ExitCode:   run.ExitCode,
+			Error:      run.Error,
+		})
+	}
+	return res, nil
+}
+
+func (s *FileStore) Close() error {
+	return nil
+}
+
+// write replaces the file atomically. It is called with s.mu held.
+func (s *FileStore) write() error {
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp.Name(), s.path)
+	if err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir makes the rename itself durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,57 @@
+package store_test
+
+import (
+	"cron_expression_parser/store"
+	"cron_expression_parser/store/storetest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func openFile(t *testing.T, dir string) store.Store {
+	s, err := store.OpenFile(filepath.Join(dir, "jobs.json"))
+	if err != nil {
+		t.Fatalf("Should open file store; %s", err)
+	}
+	return s
+}
+
+func TestFileStore(t *testing.T) {
+	storetest.Run(t, openFile)
+}
+
+func TestShouldNotLeaveTemporaryFilesBehind(t *testing.T) {
+	dir := t.TempDir()
+	s := openFile(t, dir)
+	defer s.Close()
+
+	for i := 0; i < 3; i++ {
+		err := s.SaveJob(store.JobDefinition{Name: "report", Expression: "* * * * * report.sh"})
+		if err != nil {
+			t.Fatalf("Should save job; %s", err)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || entries[0].Name() != "jobs.json" {
+		t.Fatalf("Should keep only the store file, actual: %v", entries)
+	}
+}
+
+func TestShouldLimitFileStoreHistory(t *testing.T) {
+	s, err := store.OpenFile(filepath.Join(t.TempDir(), "jobs.json"))
+	if err != nil {
+		t.Fatalf("Should open file store; %s", err)
+	}
+	s.SetHistoryLimit(2)
+
+	s.SaveJob(store.JobDefinition{Name: "report", Expression: "* * * * * report.sh"})
+	for i := 0; i < 5; i++ {
+		s.AddRun(store.Run{JobName: "report", ExitCode: i})
+	}
+
+	history, _ := s.History("report", 10)
+	if len(history) != 2 || history[0].ExitCode != 4 {
+		t.Fatalf("Should keep only the last runs, actual: %+v", history)
+	}
+}
@@ -0,0 +1,248 @@
+// Package sqlite is a store.Store kept in an embedded SQLite database, using
+// the pure Go driver so binaries stay static.
+package sqlite
+
+import (
+	"cron_expression_parser/store"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	name       TEXT PRIMARY KEY,
+	expression TEXT NOT NULL,
+	last_run   INTEGER NOT NULL DEFAULT 0,
+	next_run   INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE IF NOT EXISTS runs (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	job_name    TEXT NOT NULL REFERENCES jobs(name) ON DELETE CASCADE,
+	fire_time   INTEGER NOT NULL,
+	started_at  INTEGER NOT NULL,
+	finished_at INTEGER NOT NULL,
+	exit_code   INTEGER NOT NULL,
+	error       TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS runs_job_name ON runs(job_name, id);
+`
+
+type Store struct {
+	db *sql.DB
+
+	mu           sync.Mutex
+	historyLimit int
+}
+
+// Open opens or creates the database in path. Writes go through the write
+// ahead log, so they survive crashes once the call returns.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path+"?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(schema)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db, historyLimit: store.DefaultHistoryLimit}, nil
+}
+
+// SetHistoryLimit changes how many runs are kept per job.
+func (s *Store) SetHistoryLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.historyLimit = limit
+}
+
+func (s *Store) SaveJob(definition store.JobDefinition) error {
+	_, err := store.LoadJob(definition, time.Time{}, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT INTO jobs (name, expression) VALUES (?, ?)
+		ON CONFLICT(name) DO UPDATE SET expression = excluded.expression`, definition.Name, definition.Expression)
+	return err
+}
+
+func (s *Store) DeleteJob(name string) error {
+	res, err := s.db.Exec(`DELETE FROM jobs WHERE name = ?`, name)
+	if err != nil {
+		return err
+	}
+	return requireRow(res)
+}
+
+func (s *Store) Job(name string) (store.Job, error) {
+	var expression string
+	var lastRun, nextRun int64
+	err := s.db.QueryRow(`SELECT expression, last_run, next_run FROM jobs WHERE name = ?`, name).Scan(&expression, &lastRun, &nextRun)
+	if errors.Is(err, sql.ErrNoRows) {
+		return store.Job{}, store.ErrJobNotFound
+	}
+	if err != nil {
+		return store.Job{}, err
+	}
+	return store.LoadJob(store.JobDefinition{Name: name, Expression: expression}, fromUnix(lastRun), fromUnix(nextRun))
+}
+
+// Jobs returns all jobs ordered by name.
+func (s *Store) Jobs() ([]store.Job, error) {
+	rows, err := s.db.Query(`SELECT name, expression, last_run, next_run FROM jobs ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []store.Job{}
+	for rows.Next() {
+		var definition store.JobDefinition
+		var lastRun, nextRun int64
+		err = rows.Scan(&definition.Name, &definition.Expression, &lastRun, &nextRun)
+		if err != nil {
+			return nil, err
+		}
+
+		job, err := store.LoadJob(definition, fromUnix(lastRun), fromUnix(nextRun))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, job)
+	}
+	return res, rows.Err()
+}
+
+func (s *Store) LastRun(name string) (time.Time, bool, error) {
+	var lastRun int64
+	err := s.db.QueryRow(`SELECT last_run FROM jobs WHERE name = ?`, name).Scan(&lastRun)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if lastRun == 0 {
+		return time.Time{}, false, nil
+	}
+	return fromUnix(lastRun), true, nil
+}
+
+// SetLastRun keeps the latest of the stored and the given fire time, since
+// overlapping runs may report out of order.
+func (s *Store) SetLastRun(name string, fireTime time.Time) error {
+	res, err := s.db.Exec(`UPDATE jobs SET last_run = max(last_run, ?) WHERE name = ?`, toUnix(fireTime), name)
+	if err != nil {
+		return err
+	}
+	return requireRow(res)
+}
+
+func (s *Store) SetNextRun(name string, nextRun time.Time) error {
+	res, err := s.db.Exec(`UPDATE jobs SET next_run = ? WHERE name = ?`, toUnix(nextRun), name)
+	if err != nil {
+		return err
+	}
+	return requireRow(res)
+}
+
+// AddRun records the run and drops the oldest ones above the history limit,
+// in one transaction.
+func (s *Store) AddRun(run store.Run) error {
+	s.mu.Lock()
+	limit := s.historyLimit
+	s.mu.Unlock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`INSERT INTO runs (job_name, fire_time, started_at, finished_at, exit_code, error)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		run.JobName, toUnix(run.FireTime), toUnix(run.StartedAt), toUnix(run.FinishedAt), run.ExitCode, run.Error)
+	if err != nil && isConstraintError(err) {
+		return store.ErrJobNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM runs WHERE job_name = ? AND id NOT IN
+		(SELECT id FROM runs WHERE job_name = ? ORDER BY id DESC LIMIT ?)`, run.JobName, run.JobName, limit)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *Store) History(name string, limit int) ([]store.Run, error) {
+	_, err := s.Job(name)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`SELECT fire_time, started_at, finished_at, exit_code, error FROM runs
+		WHERE job_name = ? ORDER BY id DESC LIMIT ?`, name, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []store.Run{}
+	for rows.Next() {
+		var fireTime, startedAt, finishedAt int64
+		run := store.Run{JobName: name}
+		err = rows.Scan(&fireTime, &startedAt, &finishedAt, &run.ExitCode, &run.Error)
+		if err != nil {
+			return nil, err
+		}
+		run.FireTime, run.StartedAt, run.FinishedAt = fromUnix(fireTime), fromUnix(startedAt), fromUnix(finishedAt)
+		res = append(res, run)
+	}
+	return res, rows.Err()
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func requireRow(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return store.ErrJobNotFound
+	}
+	return nil
+}
+
+// Times are kept as Unix nanoseconds in UTC, 0 stands for the zero time.
+func toUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromUnix(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n).UTC()
+}
+
+func isConstraintError(err error) bool {
+	var sqliteErr interface{ Code() int }
+	// SQLITE_CONSTRAINT and its extended codes share the lowest byte.
+	return errors.As(err, &sqliteErr) && sqliteErr.Code()&0xff == 19
+}
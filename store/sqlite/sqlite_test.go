@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"cron_expression_parser/store"
+	"cron_expression_parser/store/storetest"
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T, dir string) store.Store {
+		s, err := Open(filepath.Join(dir, "jobs.db"))
+		if err != nil {
+			t.Fatalf("Should open sqlite store; %s", err)
+		}
+		return s
+	})
+}
+
+func TestShouldReturnDatabaseErrorsFromLastRun(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("Should open sqlite store; %s", err)
+	}
+	s.Close()
+
+	_, ok, err := s.LastRun("report")
+	if err == nil || ok {
+		t.Fatalf("Should return the error of a closed database, actual: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"cron_expression_parser/parser"
+	"errors"
+	"time"
+)
+
+var ErrJobNotFound = errors.New("Job not found")
+
+// JobDefinition is a job as it is kept in a store. The schedule is kept as
+// the original cron string, so it is parsed again with the current parser
+// rules on load.
+type JobDefinition struct {
+	Name       string
+	Expression string
+}
+
+// Job is a stored job with its schedule parsed and its bookkeeping.
+type Job struct {
+	JobDefinition
+	Schedule *parser.Schedule
+	LastRun  time.Time
+	NextRun  time.Time
+}
+
+// Run is one entry of the run history of a job.
+type Run struct {
+	JobName    string
+	FireTime   time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+	ExitCode   int
+	Error      string
+}
+
+// Store keeps job definitions, their last and next runs and their run
+// history. It can be used as scheduler.LastRunStore.
+type Store interface {
+	SaveJob(definition JobDefinition) error
+	DeleteJob(name string) error
+	Job(name string) (Job, error)
+	Jobs() ([]Job, error)
+
+	LastRun(name string) (time.Time, bool, error)
+	SetLastRun(name string, fireTime time.Time) error
+	SetNextRun(name string, nextRun time.Time) error
+
+	AddRun(run Run) error
+	// History returns up to limit most recent runs of the job, newest first.
+	History(name string, limit int) ([]Run, error)
+
+	Close() error
+}
+
+// LoadJob parses the stored expression of a job through Parser.Parse.
+func LoadJob(definition JobDefinition, lastRun, nextRun time.Time) (Job, error) {
+	p := parser.NewParser()
+	err := p.Parse(definition.Expression)
+	if err != nil {
+		return Job{}, err
+	}
+	return Job{JobDefinition: definition, Schedule: p.Schedule(), LastRun: lastRun, NextRun: nextRun}, nil
+}
@@ -0,0 +1,203 @@
+// Package storetest checks that a store.Store implementation behaves like
+// the others, so every backend is tested with the same scenarios.
+package storetest
+
+import (
+	"cron_expression_parser/store"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Open returns a store kept in dir. Calling it twice with the same dir has
+// to return the same data, as after a restart.
+type Open func(t *testing.T, dir string) store.Store
+
+var fireTime = time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+func Run(t *testing.T, open Open) {
+	t.Run("SaveAndLoadJobs", func(t *testing.T) { testSaveAndLoadJobs(t, open) })
+	t.Run("RejectInvalidExpression", func(t *testing.T) { testRejectInvalidExpression(t, open) })
+	t.Run("DeleteJob", func(t *testing.T) { testDeleteJob(t, open) })
+	t.Run("LastAndNextRun", func(t *testing.T) { testLastAndNextRun(t, open) })
+	t.Run("History", func(t *testing.T) { testHistory(t, open) })
+	t.Run("HistoryLimit", func(t *testing.T) { testHistoryLimit(t, open) })
+	t.Run("SurviveReopen", func(t *testing.T) { testSurviveReopen(t, open) })
+}
+
+func mustSave(t *testing.T, s store.Store, name, expression string) {
+	err := s.SaveJob(store.JobDefinition{Name: name, Expression: expression})
+	if err != nil {
+		t.Fatalf("Should save job %s; %s", name, err)
+	}
+}
+
+func testSaveAndLoadJobs(t *testing.T, open Open) {
+	s := open(t, t.TempDir())
+	defer s.Close()
+
+	mustSave(t, s, "report", "*/15 * * * * report.sh")
+	mustSave(t, s, "backup", "0 1 * * * backup.sh")
+	mustSave(t, s, "report", "0 * * * * report.sh --hourly")
+
+	jobs, err := s.Jobs()
+	if err != nil {
+		t.Fatalf("Should load jobs; %s", err)
+	}
+
+	if len(jobs) != 2 || jobs[0].Name != "backup" || jobs[1].Name != "report" {
+		t.Fatalf("Should load jobs ordered by name, actual: %v", jobs)
+	}
+
+	if jobs[1].Expression != "0 * * * * report.sh --hourly" || jobs[1].Schedule.Command() != "report.sh --hourly" {
+		t.Fatalf("Should update and parse saved job, actual: %q", jobs[1].Expression)
+	}
+
+	_, err = s.Job("missing")
+	if !errors.Is(err, store.ErrJobNotFound) {
+		t.Fatalf("Should return ErrJobNotFound, actual: %v", err)
+	}
+}
+
+func testRejectInvalidExpression(t *testing.T, open Open) {
+	s := open(t, t.TempDir())
+	defer s.Close()
+
+	err := s.SaveJob(store.JobDefinition{Name: "broken", Expression: "61 * * * * cmd"})
+	if err == nil {
+		t.Fatalf("Should reject invalid expression")
+	}
+}
+
+func testDeleteJob(t *testing.T, open Open) {
+	s := open(t, t.TempDir())
+	defer s.Close()
+
+	mustSave(t, s, "report", "* * * * * report.sh")
+	err := s.DeleteJob("report")
+	if err != nil {
+		t.Fatalf("Should delete job; %s", err)
+	}
+
+	jobs, _ := s.Jobs()
+	if len(jobs) != 0 {
+		t.Fatalf("Should not load deleted job, actual: %v", jobs)
+	}
+
+	if !errors.Is(s.DeleteJob("report"), store.ErrJobNotFound) {
+		t.Fatalf("Should return ErrJobNotFound for missing job")
+	}
+}
+
+func testLastAndNextRun(t *testing.T, open Open) {
+	s := open(t, t.TempDir())
+	defer s.Close()
+
+	mustSave(t, s, "report", "* * * * * report.sh")
+
+	_, ok, err := s.LastRun("report")
+	if err != nil || ok {
+		t.Fatalf("Should not return last run before the first one; %v", err)
+	}
+
+	s.SetLastRun("report", fireTime)
+	s.SetLastRun("report", fireTime.Add(-time.Minute))
+	err = s.SetNextRun("report", fireTime.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Should set next run; %s", err)
+	}
+
+	lastRun, ok, err := s.LastRun("report")
+	if err != nil || !ok || !lastRun.Equal(fireTime) {
+		t.Fatalf("Should keep the latest last run, actual: %v", lastRun)
+	}
+
+	job, err := s.Job("report")
+	if err != nil || !job.NextRun.Equal(fireTime.Add(time.Minute)) || !job.LastRun.Equal(fireTime) {
+		t.Fatalf("Should return job bookkeeping, actual: %+v; %v", job, err)
+	}
+
+	if !errors.Is(s.SetLastRun("missing", fireTime), store.ErrJobNotFound) {
+		t.Fatalf("Should return ErrJobNotFound for missing job")
+	}
+}
+
+func testHistory(t *testing.T, open Open) {
+	s := open(t, t.TempDir())
+	defer s.Close()
+
+	mustSave(t, s, "report", "* * * * * report.sh")
+	for i := 0; i < 3; i++ {
+		err := s.AddRun(store.Run{
+			JobName:    "report",
+			FireTime:   fireTime.Add(time.Duration(i) * time.Minute),
+			StartedAt:  fireTime.Add(time.Duration(i) * time.Minute),
+			FinishedAt: fireTime.Add(time.Duration(i)*time.Minute + time.Second),
+			ExitCode:   i,
+			Error:      "",
+		})
+		if err != nil {
+			t.Fatalf("Should add run; %s", err)
+		}
+	}
+
+	history, err := s.History("report", 2)
+	if err != nil {
+		t.Fatalf("Should return history; %s", err)
+	}
+
+	if len(history) != 2 || history[0].ExitCode != 2 || history[1].ExitCode != 1 {
+		t.Fatalf("Should return most recent runs first, actual: %+v", history)
+	}
+
+	if !history[0].FinishedAt.Equal(fireTime.Add(2*time.Minute + time.Second)) {
+		t.Fatalf("Should keep run times, actual: %v", history[0].FinishedAt)
+	}
+
+	if !errors.Is(s.AddRun(store.Run{JobName: "missing"}), store.ErrJobNotFound) {
+		t.Fatalf("Should return ErrJobNotFound for missing job")
+	}
+}
+
+func testHistoryLimit(t *testing.T, open Open) {
+	s := open(t, t.TempDir())
+	defer s.Close()
+
+	mustSave(t, s, "report", "* * * * * report.sh")
+	for i := 0; i < store.DefaultHistoryLimit+5; i++ {
+		err := s.AddRun(store.Run{JobName: "report", ExitCode: i})
+		if err != nil {
+			t.Fatalf("Should add run; %s", err)
+		}
+	}
+
+	history, err := s.History("report", 2*store.DefaultHistoryLimit)
+	if err != nil {
+		t.Fatalf("Should return history; %s", err)
+	}
+	if len(history) != store.DefaultHistoryLimit || history[0].ExitCode != store.DefaultHistoryLimit+4 {
+		t.Fatalf("Should keep only the last %d runs, actual: %d", store.DefaultHistoryLimit, len(history))
+	}
+}
+
+func testSurviveReopen(t *testing.T, open Open) {
+	dir := t.TempDir()
+	s := open(t, dir)
+	mustSave(t, s, "report", "* * * * * report.sh")
+	s.SetLastRun("report", fireTime)
+	s.AddRun(store.Run{JobName: "report", FireTime: fireTime, ExitCode: 3})
+	s.Close()
+
+	reopened := open(t, dir)
+	defer reopened.Close()
+
+	lastRun, ok, err := reopened.LastRun("report")
+	if err != nil || !ok || !lastRun.Equal(fireTime) {
+		t.Fatalf("Should keep last run after reopening, actual: %v", lastRun)
+	}
+
+	history, err := reopened.History("report", 10)
+	if err != nil || len(history) != 1 || history[0].ExitCode != 3 {
+		t.Fatalf("Should keep history after reopening, actual: %+v", history)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"cron_expression_parser/parser"
+	"cron_expression_parser/tui"
+	"fmt"
+	"os"
+	"time"
+)
+
+func runTUI(c *cli, args []string) int {
+	flags := c.flagSet("tui", "[expression]", "Edits an expression interactively, showing its description and next runs while typing.\nThe accepted expression is printed to stdout, the editor is drawn on stderr.")
+	localeName := flags.String("locale", "en", "language of the description (de, en, es, fr, pl)")
+	tz := flags.String("tz", "", "time zone of the next runs, e.g. America/New_York (default local)")
+	dayPolicy := dayPolicyFlag(flags)
+	args, code, ok := parseFlags(flags, args)
+	if !ok {
+		return code
+	}
+
+	if len(args) > 1 {
+		return usageError(flags, "Please provide at most one expression")
+	}
+
+	policy, err := parser.ParseDayPolicy(*dayPolicy)
+	if err != nil {
+		return usageError(flags, "%s", err)
+	}
+
+	location := time.Local
+	if *tz != "" {
+		location, err = time.LoadLocation(*tz)
+		if err != nil {
+			return usageError(flags, "Unknown time zone %q", *tz)
+		}
+	}
+
+	expression := "* * * * *"
+	if len(args) == 1 {
+		expression = args[0]
+	}
+
+	if !tui.IsTerminal(os.Stdin) {
+		return c.fail(fmt.Errorf("tui needs a terminal on stdin"))
+	}
+	restore, err := tui.MakeRaw(os.Stdin)
+	if err != nil {
+		return c.fail(err)
+	}
+
+	model := tui.NewModel(expression, tui.Config{
+		Locale:    *localeName,
+		DayPolicy: policy,
+		Now:       func() time.Time { return time.Now().In(location) },
+	})
+	err = tui.Run(os.Stdin, c.stderr, model)
+	restore()
+	if err != nil {
+		return c.fail(err)
+	}
+
+	if !model.Accepted() {
+		return exitInvalid
+	}
+	fmt.Fprintln(c.stdout, model.Expression())
+	return exitOK
+}
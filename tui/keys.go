@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+type KeyType int
+
+const (
+	KeyRune KeyType = iota
+	KeyBackspace
+	KeyLeft
+	KeyRight
+	KeyTab
+	KeyBackTab
+	KeyEnter
+	KeyClear
+	KeyQuit
+	KeyUnknown
+)
+
+// Key is a key press decoded from the terminal input.
+type Key struct {
+	Type KeyType
+	Rune rune
+}
+
+// KeyReader decodes key presses from a terminal in raw mode.
+type KeyReader struct {
+	r *bufio.Reader
+}
+
+func NewKeyReader(r io.Reader) *KeyReader {
+	return &KeyReader{r: bufio.NewReader(r)}
+}
+
+func (k *KeyReader) Next() (Key, error) {
+	b, err := k.r.ReadByte()
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch b {
+	case 3, 4:
+		return Key{Type: KeyQuit}, nil
+	case '\r', '\n':
+		return Key{Type: KeyEnter}, nil
+	case '\t':
+		return Key{Type: KeyTab}, nil
+	case 8, 127:
+		return Key{Type: KeyBackspace}, nil
+	case 21:
+		return Key{Type: KeyClear}, nil
+	case 27:
+		return k.escape()
+	}
+
+	if b < utf8.RuneSelf {
+		return Key{Type: KeyRune, Rune: rune(b)}, nil
+	}
+	k.r.UnreadByte()
+	r, _, err := k.r.ReadRune()
+	return Key{Type: KeyRune, Rune: r}, err
+}
+
+// escape decodes the CSI sequences of arrow keys and Shift+Tab. A lone
+// escape quits.
+func (k *KeyReader) escape() (Key, error) {
+	if k.r.Buffered() == 0 {
+		return Key{Type: KeyQuit}, nil
+	}
+
+	b, err := k.r.ReadByte()
+	if err != nil || (b != '[' && b != 'O') {
+		return Key{Type: KeyUnknown}, err
+	}
+
+	b, err = k.r.ReadByte()
+	if err != nil {
+		return Key{}, err
+	}
+	switch b {
+	case 'C':
+		return Key{Type: KeyRight}, nil
+	case 'D':
+		return Key{Type: KeyLeft}, nil
+	case 'Z':
+		return Key{Type: KeyBackTab}, nil
+	}
+
+	// Skip the parameters of other sequences up to their final byte.
+	for b >= '0' && b <= '?' {
+		if b, err = k.r.ReadByte(); err != nil {
+			return Key{}, err
+		}
+	}
+	return Key{Type: KeyUnknown}, nil
+}
@@ -0,0 +1,242 @@
+// Package tui is an interactive terminal editor for cron expressions. It
+// only needs ANSI escape codes, so it works over plain SSH sessions.
+package tui
+
+import (
+	"cron_expression_parser/parser"
+	"cron_expression_parser/parser/locale"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	reset   = "\x1b[0m"
+	bold    = "\x1b[1m"
+	reverse = "\x1b[7m"
+	red     = "\x1b[31m"
+	dim     = "\x1b[2m"
+)
+
+var fieldNames = [5]string{"minute", "hour", "day of month", "month", "day of week"}
+
+type Config struct {
+	// Locale of the description, locale.Default when empty.
+	Locale    string
+	DayPolicy parser.DayPolicy
+	// Now returns the time the next runs are counted from. It defaults to
+	// time.Now; its location is the location of the runs.
+	Now func() time.Time
+	// Runs is the number of next runs shown, 5 by default.
+	Runs int
+}
+
+// Model is the state of the editor: the five fields and the focused one.
+// It does not touch the terminal, see Run.
+type Model struct {
+	config Config
+	fields [5]string
+	focus  int
+	// fresh is set when the focused field was just entered, so typing
+	// replaces it instead of appending to it.
+	fresh bool
+
+	done     bool
+	accepted bool
+}
+
+func NewModel(expression string, config Config) *Model {
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	if config.Runs == 0 {
+		config.Runs = 5
+	}
+
+	m := &Model{config: config, fields: [5]string{"*", "*", "*", "*", "*"}, fresh: true}
+	for i, field := range strings.Fields(expression) {
+		if i < len(m.fields) {
+			m.fields[i] = field
+		}
+	}
+	return m
+}
+
+// Update applies a key press. Space, Tab and the arrows move between the
+// fields, typing right after entering a field replaces it. Enter accepts the
+// expression and Ctrl+C or Esc quits.
+func (m *Model) Update(key Key) {
+	field := &m.fields[m.focus]
+	switch key.Type {
+	case KeyRune:
+		if key.Rune == ' ' {
+			m.move(1)
+			return
+		}
+		if m.fresh {
+			*field = ""
+		}
+		*field += strings.ToUpper(string(key.Rune))
+		m.fresh = false
+	case KeyBackspace:
+		if runes := []rune(*field); len(runes) > 0 {
+			*field = string(runes[:len(runes)-1])
+		}
+		m.fresh = false
+	case KeyClear:
+		*field = ""
+		m.fresh = false
+	case KeyRight, KeyTab:
+		m.move(1)
+	case KeyLeft, KeyBackTab:
+		m.move(-1)
+	case KeyEnter:
+		if m.Err() == nil {
+			m.done, m.accepted = true, true
+		}
+	case KeyQuit:
+		m.done = true
+	}
+}
+
+func (m *Model) move(delta int) {
+	m.focus = (m.focus + delta + len(m.fields)) % len(m.fields)
+	m.fresh = true
+}
+
+// Done reports whether the editor should exit, and Accepted whether it
+// exits with the expression accepted by Enter.
+func (m *Model) Done() bool {
+	return m.done
+}
+
+func (m *Model) Accepted() bool {
+	return m.accepted
+}
+
+func (m *Model) Expression() string {
+	return strings.Join(m.fields[:], " ")
+}
+
+// Err returns the first error of the expression, nil when it is valid.
+func (m *Model) Err() error {
+	_, err := m.schedule()
+	return err
+}
+
+func (m *Model) schedule() (*parser.Schedule, error) {
+	for i, field := range m.fields {
+		if field == "" {
+			return nil, &parser.FieldError{Field: i, Name: fieldNames[i], Err: errors.New("Field is empty")}
+		}
+	}
+
+	schedule, err := parser.ParseTimeFields(m.Expression())
+	if err != nil {
+		return nil, err
+	}
+	return schedule.WithDayPolicy(m.config.DayPolicy), nil
+}
+
+// fieldErrors checks every field on its own, so all invalid fields can be
+// highlighted and not only the first one.
+func (m *Model) fieldErrors() [5]error {
+	errs := [5]error{}
+	for i, field := range m.fields {
+		if field == "" {
+			errs[i] = errors.New("Field is empty")
+			continue
+		}
+
+		probe := [5]string{"*", "*", "*", "*", "*"}
+		probe[i] = field
+		_, err := parser.ParseTimeFields(strings.Join(probe[:], " "))
+		var fieldErr *parser.FieldError
+		if errors.As(err, &fieldErr) && fieldErr.Field == i {
+			errs[i] = fieldErr.Err
+		} else if err != nil {
+			errs[i] = err
+		}
+	}
+	return errs
+}
+
+// View renders the editor. Lines end with "\n"; Run turns them into what
+// the terminal in raw mode expects.
+func (m *Model) View() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%sCron expression editor%s\n", bold, reset)
+	fmt.Fprintf(&sb, "%sSpace/Tab/arrows: next field, Backspace: delete, Ctrl+U: clear, Enter: accept, Esc: quit%s\n\n", dim, reset)
+
+	errs := m.fieldErrors()
+	widths := [5]int{}
+	for i := range m.fields {
+		widths[i] = max(len(fieldNames[i]), len([]rune(m.fields[i]))+1)
+	}
+
+	for i, name := range fieldNames {
+		fmt.Fprintf(&sb, "  %-*s", widths[i], name)
+	}
+	sb.WriteString("\n")
+
+	for i, field := range m.fields {
+		style := ""
+		if errs[i] != nil {
+			style += red
+		}
+		if i == m.focus {
+			style += reverse
+		}
+		cell := field
+		if i == m.focus {
+			cell += "_"
+		}
+		fmt.Fprintf(&sb, "  %s%s%s%s", style, cell, reset, strings.Repeat(" ", widths[i]-len([]rune(cell))))
+	}
+	sb.WriteString("\n\n")
+
+	hasErrors := false
+	for i, err := range errs {
+		if err != nil {
+			fmt.Fprintf(&sb, "%s%s %q: %s%s\n", red, fieldNames[i], m.fields[i], err, reset)
+			hasErrors = true
+		}
+	}
+	if hasErrors {
+		return sb.String()
+	}
+
+	schedule, err := m.schedule()
+	if err != nil {
+		fmt.Fprintf(&sb, "%s%s%s\n", red, err, reset)
+		return sb.String()
+	}
+
+	description, err := schedule.DescribeIn(m.locale())
+	if err != nil {
+		description = err.Error()
+	}
+	fmt.Fprintf(&sb, "%s%s%s\n", bold, description, reset)
+	for _, warning := range schedule.Warnings() {
+		fmt.Fprintf(&sb, "%swarning: %s%s\n", dim, warning, reset)
+	}
+
+	now := m.config.Now()
+	sb.WriteString("\nNext runs:\n")
+	runs := schedule.NextN(now, m.config.Runs)
+	if len(runs) == 0 {
+		sb.WriteString("  none within the next five years\n")
+	}
+	for _, run := range runs {
+		fmt.Fprintf(&sb, "  %s\n", run.Format("Mon 2006-01-02 15:04 MST"))
+	}
+	return sb.String()
+}
+
+func (m *Model) locale() string {
+	if m.config.Locale == "" {
+		return locale.Default
+	}
+	return m.config.Locale
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+)
+
+// Run redraws the model after every key read from in until it is done. The
+// terminal has to be in raw mode, see MakeRaw.
+func Run(in io.Reader, out io.Writer, m *Model) error {
+	io.WriteString(out, hideCursor)
+	defer io.WriteString(out, showCursor)
+
+	keys := NewKeyReader(in)
+	for !m.Done() {
+		view := strings.ReplaceAll(m.View(), "\n", "\r\n")
+		if _, err := io.WriteString(out, clearScreen+view); err != nil {
+			return err
+		}
+
+		key, err := keys.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		m.Update(key)
+	}
+	io.WriteString(out, clearScreen)
+	return nil
+}
+
+// IsTerminal reports whether f is a terminal rather than a file or pipe.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
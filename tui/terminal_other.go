@@ -0,0 +1,12 @@
+//go:build !unix
+
+package tui
+
+import (
+	"errors"
+	"os"
+)
+
+func MakeRaw(f *os.File) (func() error, error) {
+	return nil, errors.New("Interactive mode is only supported on Unix terminals")
+}
@@ -0,0 +1,33 @@
+//go:build unix
+
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// MakeRaw switches the terminal to raw mode with stty, which is available
+// wherever there is a terminal, and returns a function restoring it.
+func MakeRaw(f *os.File) (func() error, error) {
+	state, err := stty(f, "-g")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := stty(f, "raw", "-echo"); err != nil {
+		return nil, err
+	}
+	return func() error {
+		_, err := stty(f, strings.TrimSpace(state))
+		return err
+	}, nil
+}
+
+func stty(f *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = f
+	out, err := cmd.Output()
+	return string(out), err
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"bytes"
+	"cron_expression_parser/parser"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+func newTestModel(expression string) *Model {
+	return NewModel(expression, Config{Now: func() time.Time { return now }, Runs: 2})
+}
+
+func typeText(m *Model, text string) {
+	for _, r := range text {
+		m.Update(Key{Type: KeyRune, Rune: r})
+	}
+}
+
+func TestShouldEditFieldsWhileTyping(t *testing.T) {
+	m := newTestModel("")
+	typeText(m, "*/15 0 1,15 * mon-fri")
+
+	if m.Expression() != "*/15 0 1,15 * MON-FRI" || m.Err() != nil {
+		t.Fatalf("Should build expression from typed fields, actual: %q; %v", m.Expression(), m.Err())
+	}
+
+	view := m.View()
+	for _, expected := range []string{
+		"At every 15th minute past hour 0 on day-of-month 1 and 15 or on every day-of-week from Monday through Friday",
+		"Tue 2026-10-20 00:00 UTC",
+		"Tue 2026-10-20 00:15 UTC",
+	} {
+		if !strings.Contains(view, expected) {
+			t.Fatalf("Should show %q, actual:\n%s", expected, view)
+		}
+	}
+}
+
+func TestShouldDescribeWithDayPolicy(t *testing.T) {
+	m := NewModel("0 0 1-7 * MON", Config{Now: func() time.Time { return now }, Runs: 2, DayPolicy: parser.DayPolicyAnd})
+
+	view := m.View()
+	if !strings.Contains(view, "and on Monday") || !strings.Contains(view, "Mon 2026-11-02 00:00 UTC") {
+		t.Fatalf("Should describe and run the expression with the AND day policy, actual:\n%s", view)
+	}
+}
+
+func TestShouldHighlightInvalidFields(t *testing.T) {
+	m := newTestModel("61 * 1 * 8")
+
+	view := m.View()
+	if !strings.Contains(view, red+reverse+"61_"+reset) || !strings.Contains(view, red+"8"+reset) {
+		t.Fatalf("Should highlight invalid minute and day of week, actual:\n%q", view)
+	}
+
+	if !strings.Contains(view, `minute "61": Wrong range for minutes`) || !strings.Contains(view, `day of week "8":`) {
+		t.Fatalf("Should explain the invalid fields, actual:\n%s", view)
+	}
+
+	m.Update(Key{Type: KeyEnter})
+	if m.Done() {
+		t.Fatalf("Should not accept invalid expression")
+	}
+}
+
+func TestShouldMoveBetweenFieldsAndAccept(t *testing.T) {
+	m := newTestModel("0 9 * * *")
+	m.Update(Key{Type: KeyLeft})
+	typeText(m, "1-5")
+	m.Update(Key{Type: KeyTab})
+	typeText(m, "3")
+	m.Update(Key{Type: KeyBackspace})
+	typeText(m, "30")
+
+	if m.Expression() != "30 9 * * 1-5" {
+		t.Fatalf("Should edit focused fields, actual: %q", m.Expression())
+	}
+
+	m.Update(Key{Type: KeyEnter})
+	if !m.Done() || !m.Accepted() {
+		t.Fatalf("Should accept valid expression")
+	}
+}
+
+func TestShouldDecodeKeys(t *testing.T) {
+	keys := NewKeyReader(strings.NewReader("a\x7f\t\x1b[C\x1b[D\x1b[Z\x1b[3~\rü\x15\x03"))
+	expected := []Key{
+		{Type: KeyRune, Rune: 'a'}, {Type: KeyBackspace}, {Type: KeyTab}, {Type: KeyRight}, {Type: KeyLeft},
+		{Type: KeyBackTab}, {Type: KeyUnknown}, {Type: KeyEnter}, {Type: KeyRune, Rune: 'ü'}, {Type: KeyClear}, {Type: KeyQuit},
+	}
+
+	for _, want := range expected {
+		key, err := keys.Next()
+		if err != nil || key != want {
+			t.Fatalf("Should decode %+v, actual: %+v; %v", want, key, err)
+		}
+	}
+}
+
+func TestShouldRunUntilAccepted(t *testing.T) {
+	var out bytes.Buffer
+	m := newTestModel("0 9 * * *")
+
+	err := Run(strings.NewReader("\r"), &out, m)
+	if err != nil || !m.Accepted() {
+		t.Fatalf("Should accept expression; %v", err)
+	}
+
+	if !strings.Contains(out.String(), "At 09:00"+reset+"\r\n") {
+		t.Fatalf("Should draw view with terminal line endings, actual: %q", out.String())
+	}
+}